@@ -0,0 +1,15 @@
+ephemeral "betteruptime_heartbeat_url" "backup" {
+  heartbeat_id = betteruptime_heartbeat.backup.id
+}
+
+# Pass the URL to the backup job without storing it in the state
+resource "kubernetes_secret_v1" "backup" {
+  metadata {
+    name = "backup-heartbeat"
+  }
+
+  data_wo = {
+    HEARTBEAT_URL = ephemeral.betteruptime_heartbeat_url.backup.url
+  }
+  data_wo_revision = 1
+}
@@ -0,0 +1,10 @@
+ephemeral "betteruptime_policy_incident_token" "this" {
+  policy_id = betteruptime_policy.this.id
+}
+
+# Let CI report incidents without storing the token in the state
+resource "github_actions_secret" "incident_token" {
+  repository         = "example"
+  secret_name        = "BETTERUPTIME_INCIDENT_TOKEN"
+  plaintext_value_wo = ephemeral.betteruptime_policy_incident_token.this.incident_token
+}
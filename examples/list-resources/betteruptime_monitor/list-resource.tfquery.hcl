@@ -0,0 +1,6 @@
+# Find every monitor, e.g. to adopt monitors created in the UI, with:
+#   terraform query -generate-config-out=monitors.tf
+list "betteruptime_monitor" "all" {
+  provider         = betteruptime
+  include_resource = true
+}
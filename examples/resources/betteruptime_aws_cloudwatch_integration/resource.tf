@@ -15,5 +15,6 @@ resource "betteruptime_aws_cloudwatch_integration" "with_policy" {
 
 # Point CloudWatch at this URL to deliver alerts to Better Stack
 output "aws_cloudwatch_integration_webhook_url" {
-  value = betteruptime_aws_cloudwatch_integration.this.webhook_url
+  value     = betteruptime_aws_cloudwatch_integration.this.webhook_url
+  sensitive = true
 }
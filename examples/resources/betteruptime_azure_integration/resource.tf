@@ -15,5 +15,6 @@ resource "betteruptime_azure_integration" "with_policy" {
 
 # Point Azure at this URL to deliver alerts to Better Stack
 output "azure_integration_webhook_url" {
-  value = betteruptime_azure_integration.this.webhook_url
+  value     = betteruptime_azure_integration.this.webhook_url
+  sensitive = true
 }
@@ -0,0 +1,7 @@
+import {
+  to = betteruptime_catalog_attribute.this
+  identity = {
+    relation_id = "123"
+    id          = "234"
+  }
+}
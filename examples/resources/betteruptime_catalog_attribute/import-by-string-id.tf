@@ -0,0 +1,4 @@
+import {
+  to = betteruptime_catalog_attribute.this
+  id = "123/234"
+}
@@ -0,0 +1,4 @@
+import {
+  to = betteruptime_catalog_record.this
+  id = "123/234"
+}
@@ -18,5 +18,6 @@ resource "betteruptime_datadog_integration" "with_policy" {
 
 # Point Datadog at this URL to deliver alerts to Better Stack
 output "datadog_integration_webhook_url" {
-  value = betteruptime_datadog_integration.this.webhook_url
+  value     = betteruptime_datadog_integration.this.webhook_url
+  sensitive = true
 }
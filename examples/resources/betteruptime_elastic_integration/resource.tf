@@ -17,5 +17,6 @@ resource "betteruptime_elastic_integration" "with_policy" {
 
 # Point Elastic at this URL to deliver alerts to Better Stack
 output "elastic_integration_webhook_url" {
-  value = betteruptime_elastic_integration.this.webhook_url
+  value     = betteruptime_elastic_integration.this.webhook_url
+  sensitive = true
 }
@@ -15,5 +15,6 @@ resource "betteruptime_google_monitoring_integration" "with_policy" {
 
 # Point Google Monitoring at this URL to deliver alerts to Better Stack
 output "google_monitoring_integration_webhook_url" {
-  value = betteruptime_google_monitoring_integration.this.webhook_url
+  value     = betteruptime_google_monitoring_integration.this.webhook_url
+  sensitive = true
 }
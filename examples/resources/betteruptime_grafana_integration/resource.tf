@@ -15,5 +15,6 @@ resource "betteruptime_grafana_integration" "with_policy" {
 
 # Point Grafana at this URL to deliver alerts to Better Stack
 output "grafana_integration_webhook_url" {
-  value = betteruptime_grafana_integration.this.webhook_url
+  value     = betteruptime_grafana_integration.this.webhook_url
+  sensitive = true
 }
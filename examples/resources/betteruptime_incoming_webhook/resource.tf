@@ -40,7 +40,8 @@ resource "betteruptime_incoming_webhook" "simple" {
 
 # Auto-generated URL to POST alert payloads to
 output "incoming_webhook_url" {
-  value = betteruptime_incoming_webhook.simple.url
+  value     = betteruptime_incoming_webhook.simple.url
+  sensitive = true
 }
 
 # An incoming webhook that parses JSON/query-string payloads into incidents
@@ -0,0 +1,5 @@
+# Import by ID, or by the monitored URL if it's unique, e.g. id = "123".
+import {
+  to = betteruptime_monitor.this
+  id = "url=https://api.example.com"
+}
@@ -18,5 +18,6 @@ resource "betteruptime_new_relic_integration" "with_policy" {
 
 # Point New Relic at this URL to deliver alerts to Better Stack
 output "new_relic_integration_webhook_url" {
-  value = betteruptime_new_relic_integration.this.webhook_url
+  value     = betteruptime_new_relic_integration.this.webhook_url
+  sensitive = true
 }
@@ -0,0 +1,5 @@
+# Import by ID, or by its name if it's unique, e.g. id = "123".
+import {
+  to = betteruptime_policy.this
+  id = "name=Primary"
+}
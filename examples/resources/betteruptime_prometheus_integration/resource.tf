@@ -15,5 +15,6 @@ resource "betteruptime_prometheus_integration" "with_policy" {
 
 # Point Prometheus Alertmanager at this URL to deliver alerts to Better Stack
 output "prometheus_integration_webhook_url" {
-  value = betteruptime_prometheus_integration.this.webhook_url
+  value     = betteruptime_prometheus_integration.this.webhook_url
+  sensitive = true
 }
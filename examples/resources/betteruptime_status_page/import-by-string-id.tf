@@ -0,0 +1,5 @@
+# Import by ID, or by its subdomain if it's unique, e.g. id = "123".
+import {
+  to = betteruptime_status_page.this
+  id = "subdomain=acme"
+}
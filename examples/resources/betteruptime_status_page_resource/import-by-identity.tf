@@ -0,0 +1,7 @@
+import {
+  to = betteruptime_status_page_resource.this
+  identity = {
+    status_page_id = "123"
+    id             = "234"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = betteruptime_status_page_resource.this
+  id = "123/234"
+}
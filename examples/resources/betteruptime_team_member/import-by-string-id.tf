@@ -0,0 +1,5 @@
+# Team members are identified by their email, optionally prefixed with email=.
+import {
+  to = betteruptime_team_member.this
+  id = "email=jane@example.com"
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiHost binds the client to one of the two API hosts the provider talks to: the Uptime API
+// (most resources) and the Better Stack API (team members and roles).
+type apiHost struct {
+	client  *client
+	baseURL string
+}
+
+func uptimeAPI(meta interface{}) apiHost {
+	c := meta.(*client)
+	return apiHost{client: c, baseURL: c.UptimeBaseURL()}
+}
+
+func betterStackAPI(meta interface{}) apiHost {
+	c := meta.(*client)
+	return apiHost{client: c, baseURL: c.BetterStackBaseURL()}
+}
+
+// apiObject is a single JSON:API resource object.
+type apiObject[T any] struct {
+	ID         string `json:"id"`
+	Type       string `json:"type,omitempty"`
+	Attributes T      `json:"attributes"`
+}
+
+// apiDocument is a JSON:API response wrapping a single resource object.
+type apiDocument[T any] struct {
+	Data apiObject[T] `json:"data"`
+}
+
+type apiPagination struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+}
+
+// apiPage is a single page of a paginated index endpoint. Included is left raw, as only some
+// endpoints (e.g. on-call calendars) side-load related objects.
+type apiPage[E any] struct {
+	Data       []E             `json:"data"`
+	Included   json.RawMessage `json:"included"`
+	Pagination apiPagination   `json:"pagination"`
+}
+
+// apiDo sends a request with an optional JSON body and decodes the response body into out (if
+// non-nil). Any status code not listed in expected results in an *apiError. Response bodies of 204
+// and 404 are never decoded, so callers listing http.StatusNotFound as expected can check the
+// returned status code to detect a missing object.
+func apiDo(ctx context.Context, host apiHost, method, path string, in, out interface{}, expected ...int) (int, error) {
+	fields := map[string]interface{}{
+		"http_method": method,
+		"http_url":    host.baseURL + path,
+	}
+	var reqBody io.Reader
+	var reqBytes []byte
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return 0, err
+		}
+		fields["request_body"] = string(redactBody(ctx, b))
+		reqBody = bytes.NewReader(b)
+		reqBytes = b
+	}
+	var retries int
+	start := time.Now()
+	res, err := host.client.doWithBase(withRetryCounter(ctx, &retries), method, host.baseURL, path, reqBody)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	fields["retries"] = retries
+	ctx = tflog.MaskLogStrings(ctx, host.client.token)
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "API request failed", fields)
+		return 0, err
+	}
+	defer func() {
+		// Keep-Alive.
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	body, err := io.ReadAll(res.Body)
+	fields["http_status"] = res.StatusCode
+	fields["response_body"] = string(redactResponse(ctx, body, reqBytes))
+	tflog.Debug(ctx, "API request", fields)
+	if !containsInt(expected, res.StatusCode) {
+		// Error bodies end up in diagnostics, don't let them leak secrets. Field errors are parsed
+		// before redacting, so that masking can't break the structure they are parsed from.
+		return res.StatusCode, &apiError{
+			Method:      method,
+			URL:         res.Request.URL.String(),
+			StatusCode:  res.StatusCode,
+			Body:        redactResponse(ctx, body, reqBytes),
+			fieldErrors: redactFieldErrors(ctx, parseFieldErrors(body), reqBytes),
+		}
+	}
+	if err != nil {
+		return res.StatusCode, err
+	}
+	if out == nil || res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusNotFound {
+		return res.StatusCode, nil
+	}
+	return res.StatusCode, json.Unmarshal(body, out)
+}
+
+// apiGet fetches a single object. It returns false (and no error) when the API responds with 404.
+func apiGet[T any](ctx context.Context, host apiHost, path string) (*T, bool, error) {
+	var out T
+	status, err := apiDo(ctx, host, http.MethodGet, path, nil, &out, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return nil, false, err
+	}
+	if status == http.StatusNotFound {
+		return nil, false, nil
+	}
+	return &out, true, nil
+}
+
+// apiCreate POSTs in and decodes the 201 response.
+func apiCreate[T any](ctx context.Context, host apiHost, path string, in interface{}) (*T, error) {
+	var out T
+	if _, err := apiDo(ctx, host, http.MethodPost, path, in, &out, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// apiUpdate PATCHes in and decodes the 200 response.
+func apiUpdate[T any](ctx context.Context, host apiHost, path string, in interface{}) (*T, error) {
+	var out T
+	if _, err := apiDo(ctx, host, http.MethodPatch, path, in, &out, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// apiDelete deletes an object. An object that is already gone is not an error.
+func apiDelete(ctx context.Context, host apiHost, path string) error {
+	_, err := apiDo(ctx, host, http.MethodDelete, path, nil, nil, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+// apiPages iterates over the pages of a paginated index endpoint, following pagination.next until
+// the last page. Iteration stops after the first error.
+func apiPages[E any](ctx context.Context, host apiHost, path string) iter.Seq2[*apiPage[E], error] {
+	return func(yield func(*apiPage[E], error) bool) {
+		for page := 1; ; page++ {
+			var res apiPage[E]
+			if _, err := apiDo(ctx, host, http.MethodGet, apiPagePath(path, page), nil, &res, http.StatusOK); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(&res, nil) || res.Pagination.Next == "" {
+				return
+			}
+		}
+	}
+}
+
+// apiList iterates over every object of a paginated index endpoint.
+func apiList[T any](ctx context.Context, host apiHost, path string) iter.Seq2[apiObject[T], error] {
+	return func(yield func(apiObject[T], error) bool) {
+		for page, err := range apiPages[apiObject[T]](ctx, host, path) {
+			if err != nil {
+				yield(apiObject[T]{}, err)
+				return
+			}
+			for _, e := range page.Data {
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// apiFind returns every object of a paginated index endpoint for which match returns true.
+func apiFind[T any](ctx context.Context, host apiHost, path string, match func(*T) bool) ([]apiObject[T], error) {
+	var found []apiObject[T]
+	for e, err := range apiList[T](ctx, host, path) {
+		if err != nil {
+			return nil, err
+		}
+		if match(&e.Attributes) {
+			found = append(found, e)
+		}
+	}
+	return found, nil
+}
+
+func apiPagePath(path string, page int) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%spage=%d", path, sep, page)
+}
+
+func containsInt(s []int, e int) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
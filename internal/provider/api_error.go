@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// apiError is returned when the API responds with a status code the caller didn't expect.
+type apiError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       []byte
+	// fieldErrors are the validation errors parsed from the response body before Body was redacted,
+	// see FieldErrors.
+	fieldErrors []apiFieldError
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s returned %d: %s", e.Method, e.URL, e.StatusCode, string(e.Body))
+}
+
+// readOnlyError is returned instead of sending a mutating request when the provider is read-only.
+type readOnlyError struct {
+	Method string
+	URL    string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("refusing to send %s %s: the provider is configured with read_only = true", e.Method, e.URL)
+}
+
+// apiFieldError is a single validation error reported by the API. Field is empty for errors that
+// don't relate to a specific attribute.
+type apiFieldError struct {
+	Field   string
+	Message string
+}
+
+// FieldErrors parses the validation errors out of the response body. The API reports them as
+// `{"errors": {"field": ["message", ...]}}`, but plain `{"errors": ["message"]}`,
+// `{"errors": "message"}` and JSON:API style `{"errors": [{"detail": ..., "source": {"pointer": ...}}]}`
+// payloads are understood too. It returns nil when the body isn't in any of these shapes.
+func (e *apiError) FieldErrors() []apiFieldError {
+	if e.fieldErrors != nil {
+		return e.fieldErrors
+	}
+	return parseFieldErrors(e.Body)
+}
+
+func parseFieldErrors(body []byte) []apiFieldError {
+	var payload struct {
+		Errors  json.RawMessage `json:"errors"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+	var out []apiFieldError
+
+	var byField map[string]json.RawMessage
+	var list []json.RawMessage
+	var single string
+	switch {
+	case len(payload.Errors) == 0:
+	case json.Unmarshal(payload.Errors, &byField) == nil:
+		fields := make([]string, 0, len(byField))
+		for field := range byField {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			for _, message := range apiErrorMessages(byField[field]) {
+				out = append(out, apiFieldError{Field: apiErrorField(field), Message: message})
+			}
+		}
+	case json.Unmarshal(payload.Errors, &list) == nil:
+		for _, item := range list {
+			var obj struct {
+				Title  string `json:"title"`
+				Detail string `json:"detail"`
+				Field  string `json:"field"`
+				Source struct {
+					Pointer string `json:"pointer"`
+				} `json:"source"`
+			}
+			if json.Unmarshal(item, &obj) == nil {
+				message := obj.Detail
+				if message == "" {
+					message = obj.Title
+				}
+				field := obj.Field
+				if field == "" {
+					field = obj.Source.Pointer
+				}
+				out = append(out, apiFieldError{Field: apiErrorField(field), Message: message})
+				continue
+			}
+			for _, message := range apiErrorMessages(item) {
+				out = append(out, apiFieldError{Message: message})
+			}
+		}
+	case json.Unmarshal(payload.Errors, &single) == nil:
+		out = append(out, apiFieldError{Message: single})
+	}
+
+	if len(out) == 0 && payload.Message != "" {
+		out = append(out, apiFieldError{Message: payload.Message})
+	}
+	return out
+}
+
+// apiErrorMessages returns the message(s) of a single error entry, which is either a string or an
+// array of strings.
+func apiErrorMessages(raw json.RawMessage) []string {
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		return messages
+	}
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		return []string{message}
+	}
+	return []string{string(raw)}
+}
+
+// apiErrorField normalizes the different ways the API names nested fields - `steps[2].step_members[0].email`,
+// `steps.2.step_members.0.email` or a JSON pointer like `/data/attributes/steps/2/...` - into the
+// dotted form. Errors on the object as a whole (`base`) have no field.
+func apiErrorField(field string) string {
+	field = strings.TrimPrefix(field, "/data/attributes")
+	parts := strings.FieldsFunc(field, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']' || r == '/'
+	})
+	if len(parts) == 1 && parts[0] == "base" {
+		return ""
+	}
+	return strings.Join(parts, ".")
+}
+
+// apiErrorDiagnostics turns err into diagnostics. Validation errors returned by the API become one
+// diagnostic per field, each with an AttributePath resolved from the request body in (see
+// apiFieldPath). Anything else is reported as a single error diagnostic, like diag.FromErr.
+func apiErrorDiagnostics(err error, in interface{}) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var roErr *readOnlyError
+	if errors.As(err, &roErr) {
+		return readOnlyDiagnostics(fmt.Sprintf("The provider didn't send %s %s, because it's configured with read_only = true.", roErr.Method, roErr.URL))
+	}
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+	fieldErrors := apiErr.FieldErrors()
+	if len(fieldErrors) == 0 {
+		return diag.FromErr(err)
+	}
+	detail := fmt.Sprintf("%s %s returned %d.", apiErr.Method, apiErr.URL, apiErr.StatusCode)
+	var diags diag.Diagnostics
+	for _, e := range fieldErrors {
+		d := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  e.Message,
+			Detail:   detail,
+		}
+		if e.Field != "" {
+			d.Summary = fmt.Sprintf("%s %s", e.Field, e.Message)
+			d.AttributePath = apiFieldPath(in, e.Field)
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// apiFieldPath resolves a dotted API field name into the path of the matching schema attribute by
+// walking the request struct: struct fields are matched by their JSON name and renamed to their
+// mapstructure name where it differs (e.g. a policy step's `instructions_comment` is the `comment`
+// attribute), slices consume a numeric index and string-valued maps a key. It returns the longest
+// prefix that could be resolved, or nil if not even the first segment matched.
+func apiFieldPath(in interface{}, field string) cty.Path {
+	var path cty.Path
+	if in == nil {
+		return nil
+	}
+	t := reflect.TypeOf(in)
+	for _, segment := range strings.Split(field, ".") {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(segment)
+			if err != nil {
+				return path
+			}
+			path = path.IndexInt(i)
+			t = t.Elem()
+		case reflect.Map:
+			if t.Elem().Kind() == reflect.Interface {
+				// A generic map may be a nested block rather than a map attribute - don't guess.
+				return path
+			}
+			path = path.IndexString(segment)
+			t = t.Elem()
+		case reflect.Struct:
+			f, ok := apiStructField(t, segment)
+			if !ok {
+				return path
+			}
+			name := segment
+			if tag := strings.Split(f.Tag.Get("mapstructure"), ",")[0]; tag != "" {
+				name = tag
+			}
+			path = path.GetAttr(name)
+			t = f.Type
+		default:
+			return path
+		}
+	}
+	return path
+}
+
+func apiStructField(t reflect.Type, jsonName string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == jsonName {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+type apiTestItem struct {
+	Name      *string `json:"name,omitempty"`
+	TeamName  *string `json:"team_name,omitempty"`
+	CreatedAt *string `json:"created_at,omitempty"`
+}
+
+func TestAPIListFollowsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v2/items?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"one"}},{"id":"2","attributes":{"name":"two"}}],"pagination":{"next":"..."}}`))
+		case "/api/v2/items?page=2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"3","attributes":{"name":"three"}}],"pagination":{"next":null}}`))
+		default:
+			t.Errorf("Unexpected %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	var ids []string
+	for e, err := range apiList[apiTestItem](context.Background(), uptimeAPI(c), "/api/v2/items") {
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		ids = append(ids, e.ID+"="+*e.Attributes.Name)
+	}
+
+	expected := []string{"1=one", "2=two", "3=three"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+	for i := range expected {
+		if ids[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, ids)
+		}
+	}
+}
+
+func TestAPIListStopsEarly(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{}}],"pagination":{"next":"..."}}`))
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	for _, err := range apiList[apiTestItem](context.Background(), uptimeAPI(c), "/api/v2/items?filter=x") {
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		break
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request when breaking out of the first page, got %d", requests)
+	}
+}
+
+func TestAPIGetAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v2/items/1":
+			_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"one"}}}`))
+		case "/api/v2/items/2":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors":"invalid"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	out, ok, err := apiGet[apiDocument[apiTestItem]](ctx, uptimeAPI(c), "/api/v2/items/1")
+	if err != nil || !ok {
+		t.Fatalf("Expected item to be found, got ok=%v err=%v", ok, err)
+	}
+	if out.Data.ID != "1" || *out.Data.Attributes.Name != "one" {
+		t.Errorf("Unexpected item: %+v", out.Data)
+	}
+
+	if _, ok, err := apiGet[apiDocument[apiTestItem]](ctx, uptimeAPI(c), "/api/v2/items/2"); err != nil || ok {
+		t.Errorf("Expected missing item to be reported as not found, got ok=%v err=%v", ok, err)
+	}
+
+	_, err = apiCreate[apiDocument[apiTestItem]](ctx, uptimeAPI(c), "/api/v2/items", &apiTestItem{})
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *apiError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Method != http.MethodPost {
+		t.Errorf("Unexpected error: %v", apiErr)
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	err := &apiError{
+		Method:     http.MethodPost,
+		URL:        "https://uptime.betterstack.com/api/v3/policies",
+		StatusCode: http.StatusUnprocessableEntity,
+		Body:       []byte(`{"errors":{"name":["can't be blank"],"steps[2].step_members[0].email":["is not a team member"],"steps.0.instructions_comment":"is too long","base":["Policy is invalid"]}}`),
+	}
+	name := "x"
+	in := policy{Name: &name}
+
+	diags := apiErrorDiagnostics(err, &in)
+	if len(diags) != 4 {
+		t.Fatalf("Expected 4 diagnostics, got %d: %v", len(diags), diags)
+	}
+
+	expected := map[string]cty.Path{
+		"Policy is invalid":                                 nil,
+		"name can't be blank":                               cty.GetAttrPath("name"),
+		"steps.0.instructions_comment is too long":          cty.GetAttrPath("steps").IndexInt(0).GetAttr("comment"),
+		"steps.2.step_members.0.email is not a team member": cty.GetAttrPath("steps").IndexInt(2).GetAttr("step_members").IndexInt(0).GetAttr("email"),
+	}
+	for _, d := range diags {
+		path, ok := expected[d.Summary]
+		if !ok {
+			t.Errorf("Unexpected diagnostic %q", d.Summary)
+			continue
+		}
+		if !d.AttributePath.Equals(path) {
+			t.Errorf("Diagnostic %q: expected path %#v, got %#v", d.Summary, path, d.AttributePath)
+		}
+	}
+
+	raw := &apiError{Method: http.MethodGet, URL: "/x", StatusCode: http.StatusInternalServerError, Body: []byte("oops")}
+	if diags := apiErrorDiagnostics(raw, nil); len(diags) != 1 || diags[0].Summary != raw.Error() {
+		t.Errorf("Expected unparseable body to be reported as is, got %v", diags)
+	}
+}
+
+func TestAPIErrorRedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":{"environment_variables":["PASSWORD=\"p4ss\" is not allowed"],"url":["s3cret\/x is not a valid URL"]},"data":{"auth_password":"s3cret/x"}}`))
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), sensitiveKeysKey{}, sensitiveKeys(newMonitorResource().Schema))
+
+	password := "s3cret/x"
+	env := map[string]string{"PASSWORD": `"p4ss"`}
+	in := monitor{AuthPassword: &password, EnvironmentVariables: &env}
+	_, err = apiCreate[monitorHTTPResponse](ctx, uptimeAPI(c), "/api/v2/monitors", &in)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	for _, secret := range []string{"s3cret", "p4ss"} {
+		if strings.Contains(err.Error(), secret) {
+			t.Errorf("Expected %q to be redacted, got %v", secret, err)
+		}
+	}
+	for _, d := range apiErrorDiagnostics(err, &in) {
+		if strings.Contains(d.Summary, "s3cret") || strings.Contains(d.Summary, "p4ss") {
+			t.Errorf("Expected diagnostic to be redacted, got %q", d.Summary)
+		}
+	}
+}
+
+func TestAPIErrorFieldErrorsParsedBeforeRedacting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"errors":{"auth_password":["is too short: s3cret/x"]}}`))
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), sensitiveKeysKey{}, sensitiveKeys(newMonitorResource().Schema))
+
+	// A sensitive value equal to a key of the error payload doesn't break parsing it.
+	password := "errors"
+	in := monitor{AuthPassword: &password}
+	_, err = apiCreate[monitorHTTPResponse](ctx, uptimeAPI(c), "/api/v2/monitors", &in)
+	diags := apiErrorDiagnostics(err, &in)
+	if len(diags) != 1 || diags[0].Summary != "auth_password is too short: s3cret/x" || pathString(diags[0].AttributePath) != "auth_password" {
+		t.Errorf("Expected a field error on auth_password, got %v", diags)
+	}
+}
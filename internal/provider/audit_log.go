@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// auditLogs holds the audit logs opened by the process, so that provider configurations writing to
+// the same file share one handle and don't interleave their lines.
+var auditLogs = struct {
+	sync.Mutex
+	logs map[string]*auditLog
+}{logs: map[string]*auditLog{}}
+
+// auditLog appends one JSON line per mutating API request to a file, see client.doWithBase.
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// auditEntry is a line of the audit log. Terraform doesn't tell providers the address of the
+// resource being changed, only its type.
+type auditEntry struct {
+	Timestamp    string          `json:"timestamp"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	ResourceType string          `json:"resource_type,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	StatusCode   int             `json:"status_code,omitempty"`
+	ObjectID     string          `json:"object_id,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+type resourceTypeKey struct{}
+
+// openAuditLog returns the process-wide audit log writing to path, opening it on first use.
+func openAuditLog(path string) (*auditLog, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("audit_log_path: %w", err)
+	}
+	auditLogs.Lock()
+	defer auditLogs.Unlock()
+	if l, ok := auditLogs.logs[abs]; ok {
+		return l, nil
+	}
+	f, err := os.OpenFile(abs, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("audit_log_path: %w", err)
+	}
+	l := &auditLog{file: f}
+	auditLogs.logs[abs] = l
+	return l, nil
+}
+
+// record appends the entry for a request. The ID of the object is taken from the JSON:API response,
+// whose body is buffered so that the caller can still read it.
+func (l *auditLog) record(ctx context.Context, method, path string, body []byte, res *http.Response, reqErr error) {
+	entry := auditEntry{
+		Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+		Method:    method,
+		Path:      path,
+	}
+	entry.ResourceType, _ = ctx.Value(resourceTypeKey{}).(string)
+	if len(body) > 0 {
+		redacted := redactBody(ctx, body)
+		if !json.Valid(redacted) {
+			redacted, _ = json.Marshal(string(redacted))
+		}
+		entry.RequestBody = redacted
+	}
+	if reqErr != nil {
+		entry.Error = reqErr.Error()
+	}
+	if res != nil {
+		entry.StatusCode = res.StatusCode
+		b, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		var doc apiDocument[json.RawMessage]
+		if json.Unmarshal(b, &doc) == nil {
+			entry.ObjectID = doc.Data.ID
+		}
+	}
+	if entry.ObjectID == "" && method != http.MethodPost {
+		// DELETE responds without a body. Updates and deletes address the object by its path.
+		entry.ObjectID = auditObjectID(path)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		tflog.Error(ctx, "Failed to write the audit log", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		tflog.Error(ctx, "Failed to write the audit log", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+// auditObjectID returns the ID of the object addressed by an API path, e.g. 123 for
+// /api/v2/monitors/123.
+func auditObjectID(path string) string {
+	path, _, _ = strings.Cut(path, "?")
+	id, err := url.PathUnescape(path[strings.LastIndex(path, "/")+1:])
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// withResourceType wraps the CRUD functions of r so that the audit log knows which type of resource
+// API calls made by them are for.
+func withResourceType(name string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = withResourceTypeFunc(r.CreateContext, name)
+	r.ReadContext = withResourceTypeFunc(r.ReadContext, name)
+	r.UpdateContext = withResourceTypeFunc(r.UpdateContext, name)
+	r.DeleteContext = withResourceTypeFunc(r.DeleteContext, name)
+	return r
+}
+
+func withResourceTypeFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F, name string) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(context.WithValue(ctx, resourceTypeKey{}, name), d, meta)
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"id":"123","attributes":{}}}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "foo", AuditLogPath: path})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), sensitiveKeysKey{}, sensitiveKeys(newMonitorResource().Schema))
+	ctx = context.WithValue(ctx, resourceTypeKey{}, "betteruptime_monitor")
+	in := map[string]interface{}{"url": "https://example.com", "auth_password": "secret"}
+	var out monitorHTTPResponse
+	if _, err := apiDo(ctx, uptimeAPI(c), http.MethodPost, "/api/v2/monitors", in, &out, http.StatusCreated); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	if out.Data.ID != "123" {
+		t.Errorf("Expected the response to be readable after auditing, got %q", out.Data.ID)
+	}
+	if _, err := apiDo(ctx, uptimeAPI(c), http.MethodGet, "/api/v2/monitors/123", nil, &out, http.StatusOK); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	if _, err := apiDo(ctx, uptimeAPI(c), http.MethodDelete, "/api/v2/monitors/456", nil, nil, http.StatusNoContent); err != nil {
+		t.Fatalf("DELETE failed: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected only the POST and DELETE requests to be logged, got %q", lines)
+	}
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Timestamp == "" || entry.Method != http.MethodPost || entry.Path != "/api/v2/monitors" || entry.ResourceType != "betteruptime_monitor" || entry.StatusCode != http.StatusCreated || entry.ObjectID != "123" {
+		t.Errorf("Unexpected audit log entry %s", lines[0])
+	}
+	if expected := `{"auth_password":"***","url":"https://example.com"}`; string(entry.RequestBody) != expected {
+		t.Errorf("Expected request body %s, got %s", expected, entry.RequestBody)
+	}
+
+	// DELETE responds without a body, the ID is taken from the path.
+	entry = auditEntry{}
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry.Method != http.MethodDelete || entry.StatusCode != http.StatusNoContent || entry.ObjectID != "456" {
+		t.Errorf("Unexpected audit log entry %s", lines[1])
+	}
+}
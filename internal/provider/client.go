@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,12 @@ import (
 
 func rateLimitRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
 	if err != nil {
+		if isCreateRequest(ctx) {
+			// The API may have created the object before the connection broke. Don't retry blindly,
+			// let the caller reconcile instead (see resourceCreateReconciled).
+			_, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+			return false, checkErr
+		}
 		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
 	}
 
@@ -20,28 +27,73 @@ func rateLimitRetryPolicy(ctx context.Context, resp *http.Response, err error) (
 		return true, nil
 	}
 
+	if isCreateRequest(ctx) && resp.StatusCode >= 500 {
+		return false, nil
+	}
+
 	return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
 }
 
+type retryCounterKey struct{}
+
+// withRetryCounter has the client store the number of retries of a request in retries.
+func withRetryCounter(ctx context.Context, retries *int) context.Context {
+	return context.WithValue(ctx, retryCounterKey{}, retries)
+}
+
+type createRequestKey struct{}
+
+// withCreateRequest marks requests creating an object, which must not be retried automatically when
+// it's unknown whether the API processed them. Retrying would create a duplicate.
+func withCreateRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, createRequestKey{}, true)
+}
+
+func isCreateRequest(ctx context.Context) bool {
+	v, _ := ctx.Value(createRequestKey{}).(bool)
+	return v
+}
+
 type client struct {
 	baseURL            string
 	betterStackBaseURL string
 	token              string
 	retryClient        *retryablehttp.Client
 	userAgent          string
-	rateLimiter        *rate.Limiter
+	rateLimiter        *adaptiveLimiter
+	tokenScope         tokenScope
+	defaultTeamName    string
+	// defaultNotifications holds the notification settings of the provider's default_notifications
+	// block that are set, see applyDefaultNotifications.
+	defaultNotifications map[string]interface{}
+	// defaultMetadata holds the provider's default_metadata, see applyDefaultMetadata.
+	defaultMetadata map[string]string
+	// readOnly makes the client reject every request but GET, see withReadOnly.
+	readOnly bool
+	auditLog *auditLog
 }
 
 type ClientConfig struct {
-	BaseURL      string
-	Token        string
-	UserAgent    string
-	HTTPClient   *http.Client
-	RetryMax     int
-	RetryWaitMin time.Duration
-	RetryWaitMax time.Duration
-	RateLimit    int // requests per second, 0 = no limit
-	RateBurst    int // burst size for rate limiter, 0 = use default
+	BaseURL string
+	// BetterStackBaseURL is the base URL of the Better Stack API, used for team members and roles.
+	// Defaults to https://betterstack.com.
+	BetterStackBaseURL string
+	Token              string
+	UserAgent          string
+	HTTPClient         *http.Client
+	RetryMax           int
+	RetryWaitMin       time.Duration
+	RetryWaitMax       time.Duration
+	RateLimit          int // requests per second, 0 = no limit
+	RateBurst          int // burst size for rate limiter, 0 = use default
+	// ShareRateLimit makes the client share its rate limiter with every other client in the process
+	// using the same BaseURL and Token, and the configured rate with other processes, so that they
+	// stay within one budget together.
+	ShareRateLimit bool
+	// ReadOnly makes the client refuse to send POST, PATCH and DELETE requests.
+	ReadOnly bool
+	// AuditLogPath is the file POST, PATCH and DELETE requests are appended to as JSON lines.
+	AuditLogPath string
 }
 
 func newClient(config ClientConfig) (*client, error) {
@@ -63,22 +115,29 @@ func newClient(config ClientConfig) (*client, error) {
 	retryClient.RetryWaitMin = config.RetryWaitMin
 	retryClient.RetryWaitMax = config.RetryWaitMax
 	retryClient.CheckRetry = rateLimitRetryPolicy
-	retryClient.Backoff = retryablehttp.DefaultBackoff
+	retryClient.Backoff = rateLimitBackoff
 
 	// Use custom HTTP client if provided
 	if config.HTTPClient != nil {
 		retryClient.HTTPClient = config.HTTPClient
 	}
 
-	// Disable default logging
-	retryClient.RequestLogHook = nil
-	retryClient.ResponseLogHook = nil
+	// Disable default logging, API requests are logged by apiDo. Count the retries it logs.
+	retryClient.Logger = nil
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, req *http.Request, attempt int) {
+		if retries, ok := req.Context().Value(retryCounterKey{}).(*int); ok {
+			*retries = attempt
+		}
+	}
 	retryClient.ErrorHandler = nil
 
-	// Create rate limiter if specified
-	var rateLimiter *rate.Limiter
+	// Create rate limiter. Without a configured limit it only slows down when the API reports the
+	// quota is running out.
+	limit := rate.Inf
+	burst := 10
 	if config.RateLimit > 0 {
-		burst := config.RateBurst
+		limit = rate.Limit(config.RateLimit)
+		burst = config.RateBurst
 		if burst <= 0 {
 			// Default burst: allow accumulating up to 2 seconds worth of requests
 			// This handles Terraform's pattern of idle-then-busy well
@@ -87,13 +146,34 @@ func newClient(config ClientConfig) (*client, error) {
 				burst = 10 // Minimum burst of 10 for reasonable performance
 			}
 		}
-		rateLimiter = rate.NewLimiter(rate.Limit(config.RateLimit), burst)
+	}
+	var rateLimiter *adaptiveLimiter
+	if config.ShareRateLimit {
+		rateLimiter = sharedAdaptiveLimiter(config.BaseURL, config.Token, limit, burst, config.RetryWaitMax)
+	} else {
+		rateLimiter = newAdaptiveLimiter(limit, burst, config.RetryWaitMax)
 	}
 
-	betterStackBaseURL := "https://betterstack.com"
-	// Override with test URL if baseURL is not the production URL
-	if config.BaseURL != "https://uptime.betterstack.com" {
-		betterStackBaseURL = config.BaseURL
+	// Adapt the rate to the quota reported on every response, including retried attempts, and have
+	// retries wait for the rate limiter too.
+	retryClient.ResponseLogHook = func(_ retryablehttp.Logger, resp *http.Response) {
+		rateLimiter.observe(resp)
+	}
+	retryClient.PrepareRetry = func(req *http.Request) error {
+		return rateLimiter.Wait(req.Context())
+	}
+
+	var audit *auditLog
+	if config.AuditLogPath != "" {
+		var err error
+		if audit, err = openAuditLog(config.AuditLogPath); err != nil {
+			return nil, err
+		}
+	}
+
+	betterStackBaseURL := config.BetterStackBaseURL
+	if betterStackBaseURL == "" {
+		betterStackBaseURL = defaultBetterStackURL
 	}
 
 	return &client{
@@ -103,6 +183,8 @@ func newClient(config ClientConfig) (*client, error) {
 		retryClient:        retryClient,
 		userAgent:          config.UserAgent,
 		rateLimiter:        rateLimiter,
+		readOnly:           config.ReadOnly,
+		auditLog:           audit,
 	}, nil
 }
 
@@ -130,24 +212,24 @@ func (c *client) Delete(ctx context.Context, path string) (*http.Response, error
 	return c.doWithBase(ctx, http.MethodDelete, c.baseURL, path, nil)
 }
 
-func (c *client) GetWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
-	return c.doWithBase(ctx, http.MethodGet, baseURL, path, nil)
-}
-
-func (c *client) PostWithBaseURL(ctx context.Context, baseURL, path string, body io.Reader) (*http.Response, error) {
-	return c.doWithBase(ctx, http.MethodPost, baseURL, path, body)
-}
+func (c *client) doWithBase(ctx context.Context, method, baseURL, path string, body io.Reader) (*http.Response, error) {
+	mutating := method != http.MethodGet && method != http.MethodHead
+	if c.readOnly && mutating {
+		return nil, &readOnlyError{Method: method, URL: baseURL + path}
+	}
 
-func (c *client) DeleteWithBaseURL(ctx context.Context, baseURL, path string) (*http.Response, error) {
-	return c.doWithBase(ctx, http.MethodDelete, baseURL, path, nil)
-}
+	// Apply rate limiting
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
 
-func (c *client) doWithBase(ctx context.Context, method, baseURL, path string, body io.Reader) (*http.Response, error) {
-	// Apply rate limiting if configured
-	if c.rateLimiter != nil {
-		if err := c.rateLimiter.Wait(ctx); err != nil {
-			return nil, fmt.Errorf("rate limiter: %w", err)
+	var audited []byte
+	if c.auditLog != nil && mutating && body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
 		}
+		audited, body = b, bytes.NewReader(b)
 	}
 
 	req, err := retryablehttp.NewRequest(method, fmt.Sprintf("%s%s", baseURL, path), body)
@@ -161,5 +243,9 @@ func (c *client) doWithBase(ctx context.Context, method, baseURL, path string, b
 	if method == http.MethodPost || method == http.MethodPatch {
 		req.Header.Set("Content-Type", "application/json")
 	}
-	return c.retryClient.Do(req.WithContext(ctx))
+	res, err := c.retryClient.Do(req.WithContext(ctx))
+	if c.auditLog != nil && mutating {
+		c.auditLog.record(ctx, method, path, audited, res, err)
+	}
+	return res, err
 }
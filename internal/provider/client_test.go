@@ -2,8 +2,14 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -292,3 +298,382 @@ func TestClientBurstBehavior(t *testing.T) {
 		t.Errorf("Rate limited request completed too quickly: %v (expected >= 400ms)", limitedDuration)
 	}
 }
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	var requestTimes []time.Time
+	var mu sync.Mutex
+
+	// Create a test server that asks to retry after 1 second on the first request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		count := len(requestTimes)
+		mu.Unlock()
+
+		if count == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create client with a minimum retry interval much shorter than Retry-After
+	client, err := newClient(ClientConfig{
+		BaseURL:      server.URL,
+		Token:        "test-token",
+		RetryMax:     2,
+		RetryWaitMin: 10 * time.Millisecond,
+		RetryWaitMax: 2 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requestTimes) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requestTimes))
+	}
+	if gap := requestTimes[1].Sub(requestTimes[0]); gap < 900*time.Millisecond {
+		t.Errorf("Retry was sent %v after the 429 (expected at least 1s from Retry-After)", gap)
+	}
+}
+
+func TestClientClampsRetryAfter(t *testing.T) {
+	var requests int
+	var mu sync.Mutex
+
+	// Create a test server that asks to retry after an hour on the first request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		count := requests
+		mu.Unlock()
+
+		if count == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := newClient(ClientConfig{
+		BaseURL:      server.URL,
+		Token:        "test-token",
+		RetryMax:     2,
+		RetryWaitMin: 10 * time.Millisecond,
+		RetryWaitMax: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	resp, err := client.Get(context.Background(), "/test")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	// Both the retry backoff and the rate limiter pause are limited to RetryWaitMax
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Request took %v (expected the wait to be limited to 50ms)", elapsed)
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+}
+
+func TestClientSlowsDownNearQuota(t *testing.T) {
+	var requestTimes []time.Time
+	var mu sync.Mutex
+
+	// Create a test server reporting only 1 request left in a window resetting in 2 seconds
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		mu.Unlock()
+
+		w.Header().Set("X-RateLimit-Remaining", "1")
+		w.Header().Set("X-RateLimit-Reset", "2")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create client with a high rate limit but no burst capacity
+	client, err := newClient(ClientConfig{
+		BaseURL:   server.URL,
+		Token:     "test-token",
+		RateLimit: 100,
+		RateBurst: 1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(ctx, "/test")
+		if err != nil {
+			t.Fatalf("Request %d failed: %v", i+1, err)
+		}
+		resp.Body.Close()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// 1 request left over 2 seconds means the limiter drops to 0.5 requests per second
+	if gap := requestTimes[1].Sub(requestTimes[0]); gap < 1500*time.Millisecond {
+		t.Errorf("Requests are %v apart (expected the limiter to slow down to ~2s)", gap)
+	}
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", "5")
+	h.Set("X-RateLimit-Reset", "30")
+	remaining, reset, ok := parseRateLimitHeaders(h, now)
+	if !ok || remaining != 5 || !reset.Equal(now.Add(30*time.Second)) {
+		t.Errorf("Relative reset: got remaining=%d reset=%v ok=%v", remaining, reset, ok)
+	}
+
+	h = http.Header{}
+	h.Set("RateLimit-Remaining", "0")
+	h.Set("RateLimit-Reset", "1700000060")
+	remaining, reset, ok = parseRateLimitHeaders(h, now)
+	if !ok || remaining != 0 || !reset.Equal(time.Unix(1700000060, 0)) {
+		t.Errorf("Absolute reset: got remaining=%d reset=%v ok=%v", remaining, reset, ok)
+	}
+
+	if _, _, ok := parseRateLimitHeaders(http.Header{}, now); ok {
+		t.Errorf("Expected missing headers not to be parsed")
+	}
+
+	h = http.Header{}
+	h.Set("Retry-After", now.Add(10*time.Second).UTC().Format(http.TimeFormat))
+	if wait, ok := parseRetryAfter(h, now); !ok || wait != 10*time.Second {
+		t.Errorf("Retry-After date: got wait=%v ok=%v", wait, ok)
+	}
+}
+
+func TestClientSharedRateLimit(t *testing.T) {
+	var requestTimes []time.Time
+	var mu sync.Mutex
+
+	dir := sharedRateLimitDir
+	sharedRateLimitDir = t.TempDir()
+	defer func() { sharedRateLimitDir = dir }()
+
+	// Create a test server that records request times
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// Create two clients sharing the token, like two aliases of the provider would
+	var clients []*client
+	for i := 0; i < 2; i++ {
+		c, err := newClient(ClientConfig{
+			BaseURL:        server.URL,
+			Token:          "shared-token",
+			RateLimit:      2,
+			RateBurst:      1,
+			ShareRateLimit: true,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		clients = append(clients, c)
+	}
+	if clients[0].rateLimiter != clients[1].rateLimiter {
+		t.Fatalf("Expected clients using the same token to share the rate limiter")
+	}
+
+	// Alternate requests between the clients - together they must stay within 2 requests per second
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		resp, err := clients[i%2].Get(ctx, "/test")
+		if err != nil {
+			t.Fatalf("Request %d failed: %v", i+1, err)
+		}
+		resp.Body.Close()
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i < len(requestTimes); i++ {
+		if gap := requestTimes[i].Sub(requestTimes[i-1]); gap < 450*time.Millisecond {
+			t.Errorf("Requests %d and %d are too close: %v apart (expected at least 450ms)", i, i+1, gap)
+		}
+	}
+
+	// A different token gets its own budget
+	other, err := newClient(ClientConfig{BaseURL: server.URL, Token: "other-token", RateLimit: 2, RateBurst: 1, ShareRateLimit: true})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	if other.rateLimiter == clients[0].rateLimiter {
+		t.Errorf("Expected clients using different tokens not to share the rate limiter")
+	}
+}
+
+func TestClientReadOnly(t *testing.T) {
+	var requestCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": "success"}`))
+	}))
+	defer server.Close()
+
+	client, err := newClient(ClientConfig{
+		BaseURL:  server.URL,
+		Token:    "test-token",
+		ReadOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx := context.Background()
+	resp, err := client.Get(ctx, "/test")
+	if err != nil {
+		t.Fatalf("GET request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if _, err := client.Post(ctx, "/test", strings.NewReader(`{}`)); err == nil || !strings.Contains(err.Error(), "read_only") {
+		t.Errorf("Expected POST to be refused, got %v", err)
+	}
+	if _, err := client.Patch(ctx, "/test", strings.NewReader(`{}`)); err == nil {
+		t.Error("Expected PATCH to be refused")
+	}
+	if _, err := client.Delete(ctx, "/test"); err == nil {
+		t.Error("Expected DELETE to be refused")
+	}
+
+	// Only the GET request reached the server.
+	if finalCount := atomic.LoadInt32(&requestCount); finalCount != 1 {
+		t.Errorf("Expected 1 request, got %d", finalCount)
+	}
+}
+
+func TestSharedBudget(t *testing.T) {
+	// Two budgets on the same state file, like two plugin processes for aliases of the provider
+	path := filepath.Join(t.TempDir(), "budget")
+	budgets := []*sharedBudget{
+		{path: path, interval: 200 * time.Millisecond, burst: 1},
+		{path: path, interval: 200 * time.Millisecond, burst: 1},
+	}
+
+	// A lock file left behind by a process that died while holding it is broken
+	if err := os.WriteFile(path+".lock", nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * sharedBudgetLockStale)
+	if err := os.Chtimes(path+".lock", stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := budgets[i%2].Wait(ctx); err != nil {
+			t.Fatalf("Wait %d failed: %v", i+1, err)
+		}
+	}
+	// Together they must stay within 5 requests per second: the first is immediate, the rest 200ms apart
+	if elapsed := time.Since(start); elapsed < 550*time.Millisecond {
+		t.Errorf("4 requests took %v (expected at least 600ms)", elapsed)
+	}
+
+	if _, err := os.Stat(path + ".lock"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected the lock file to be removed, got %v", err)
+	}
+}
+
+func TestSharedBudgetInvalidState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "budget")
+	budget := &sharedBudget{path: path, interval: time.Second, burst: 1, maxPause: 100 * time.Millisecond}
+
+	// A time far in the future, e.g. written with a wrong clock, is reset rather than waited for
+	if err := os.WriteFile(path, []byte(strconv.FormatInt(time.Now().Add(24*time.Hour).UnixNano(), 10)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if err := budget.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > budget.maxPause {
+		t.Errorf("Wait took %v (expected at most %v)", elapsed, budget.maxPause)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := strconv.ParseInt(string(data), 10, 64); time.Unix(0, n).After(time.Now().Add(budget.interval)) {
+		t.Errorf("Expected the state to be reset, got %s", time.Unix(0, n))
+	}
+	if matches, _ := filepath.Glob(path + ".*"); len(matches) != 0 {
+		t.Errorf("Expected no temporary or lock files, got %v", matches)
+	}
+
+	// A waiting request never waits longer than maxPause at once
+	if err := budget.Wait(ctx); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second+budget.maxPause {
+		t.Errorf("Wait took %v", elapsed)
+	}
+}
+
+func TestSharedBudgetDir(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows doesn't report directory permissions")
+	}
+	dir := filepath.Join(t.TempDir(), "shared")
+	if err := os.Mkdir(dir, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(dir, 0o777); err != nil {
+		t.Fatal(err)
+	}
+	budget := &sharedBudget{path: filepath.Join(dir, "budget"), interval: time.Second, burst: 1}
+	if err := budget.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o700 {
+		t.Errorf("Expected the directory to be made accessible only to its owner, got %v", info.Mode())
+	}
+
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(t.TempDir(), link); err != nil {
+		t.Fatal(err)
+	}
+	budget = &sharedBudget{path: filepath.Join(link, "budget"), interval: time.Second, burst: 1}
+	if err := budget.Wait(context.Background()); err == nil {
+		t.Error("Expected a symlinked directory to be refused")
+	}
+}
@@ -3,8 +3,6 @@ package provider
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"io"
 	"net"
 	"net/http"
 	"sort"
@@ -22,27 +20,9 @@ func newIpListDataSource() *schema.Resource {
 }
 
 func ipListLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	res, err := meta.(*client).Get(ctx, "/ips-by-cluster.json")
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-
-	// Unmarshal the JSON into a map
+	// The IP list is a plain JSON object keyed by cluster, not a JSON:API document.
 	var ipData map[string][]string
-	err = json.Unmarshal(body, &ipData)
-	if err != nil {
+	if _, err := apiDo(ctx, uptimeAPI(meta), http.MethodGet, "/ips-by-cluster.json", nil, &ipData, http.StatusOK); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -2,10 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +28,12 @@ func newMonitorDataSource() *schema.Resource {
 		s[k] = &cp
 	}
 	delete(s, "team_name")
+	delete(s, "default_notifications_applied")
+	delete(s, "default_metadata")
+	for _, k := range []string{"auth_password", "environment_variables"} {
+		delete(s, k+"_wo")
+		delete(s, k+"_wo_version")
+	}
 	return &schema.Resource{
 		ReadContext: monitorLookup,
 		Description: "Monitor lookup.",
@@ -39,61 +41,21 @@ func newMonitorDataSource() *schema.Resource {
 	}
 }
 
-type monitorPageHTTPResponse struct {
-	Data []struct {
-		ID         string  `json:"id"`
-		Attributes monitor `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func monitorLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*monitorPageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v2/monitors?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr monitorPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	url := d.Get("url").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range apiList[monitor](ctx, uptimeAPI(meta), "/api/v2/monitors") {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if e.Attributes.URL != nil && *e.Attributes.URL == url {
-				if d.Id() != "" {
-					return diag.Errorf("duplicate")
-				}
-				d.SetId(e.ID)
-				if derr := monitorCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if e.Attributes.URL != nil && *e.Attributes.URL == url {
+			if d.Id() != "" {
+				return diag.Errorf("duplicate")
+			}
+			d.SetId(e.ID)
+			if derr := monitorCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -48,115 +46,58 @@ func onCallCalendarDefaultOrLookup(ctx context.Context, d *schema.ResourceData,
 	return onCallDefaultCalendar(ctx, d, meta)
 }
 
+type onCallCalendarObject struct {
+	ID            string              `json:"id"`
+	Attributes    onCallCalendar      `json:"attributes"`
+	Relationships onCallRelationships `json:"relationships"`
+}
+
 type onCallDefaultCalendarHTTPResponse struct {
-	Data struct {
-		ID            string              `json:"id"`
-		Attributes    onCallCalendar      `json:"attributes"`
-		Relationships onCallRelationships `json:"relationships"`
-	} `json:"data"`
-	Included []onCallIncluded `json:"included"`
+	Data     onCallCalendarObject `json:"data"`
+	Included []onCallIncluded     `json:"included"`
 }
 
 func onCallDefaultCalendar(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func() (*onCallDefaultCalendarHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, "/api/v2/on-calls/default")
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr onCallDefaultCalendarHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
-	res, err := fetch()
+	res, ok, err := apiGet[onCallDefaultCalendarHTTPResponse](ctx, uptimeAPI(meta), "/api/v2/on-calls/default")
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(res.Data.ID)
-
-	var outRotation onCallRotation
-	if err, ok := resourceRead(ctx, meta, fmt.Sprintf("/api/v2/on-calls/%s/rotation", url.PathEscape(res.Data.ID)), &outRotation); err != nil {
-		return err
-	} else if !ok {
-		return onCallCalendarCopyAttrs(d, &res.Data.Attributes, res.Data.Relationships, res.Included, nil)
+	if !ok {
+		return diag.Errorf("Default on-call calendar not found")
 	}
-
-	return onCallCalendarCopyAttrs(d, &res.Data.Attributes, res.Data.Relationships, res.Included, &outRotation)
-}
-
-type onCallCalendarsPageHTTPResponse struct {
-	Data []struct {
-		ID            string              `json:"id"`
-		Attributes    onCallCalendar      `json:"attributes"`
-		Relationships onCallRelationships `json:"relationships"`
-	} `json:"data"`
-	Included   []onCallIncluded `json:"included"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
+	d.SetId(res.Data.ID)
+	return onCallCalendarCopyWithRotation(ctx, d, meta, &res.Data, res.Included)
 }
 
 func onCallCalendarLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*onCallCalendarsPageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v2/on-calls?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr onCallCalendarsPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	calendarName := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for page, err := range apiPages[onCallCalendarObject](ctx, uptimeAPI(meta), "/api/v2/on-calls") {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
+		for _, e := range page.Data {
 			if e.Attributes.Name != nil && *e.Attributes.Name == calendarName {
-				if d.Id() != "" {
-					return diag.Errorf("There are multiple on-call calendars with the same name: %s", calendarName)
+				var included []onCallIncluded
+				if len(page.Included) > 0 {
+					if err := json.Unmarshal(page.Included, &included); err != nil {
+						return diag.FromErr(err)
+					}
 				}
 				d.SetId(e.ID)
-
-				var outRotation onCallRotation
-				if err, ok := resourceRead(ctx, meta, fmt.Sprintf("/api/v2/on-calls/%s/rotation", url.PathEscape(e.ID)), &outRotation); err != nil {
-					return err
-				} else if !ok {
-					return onCallCalendarCopyAttrs(d, &e.Attributes, e.Relationships, res.Included, nil)
-				}
-
-				return onCallCalendarCopyAttrs(d, &e.Attributes, e.Relationships, res.Included, &outRotation)
+				return onCallCalendarCopyWithRotation(ctx, d, meta, &e, included)
 			}
 		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
-		}
 	}
+	return nil
+}
+
+// onCallCalendarCopyWithRotation fetches the calendar's rotation (if it has one) and copies both into d.
+func onCallCalendarCopyWithRotation(ctx context.Context, d *schema.ResourceData, meta interface{}, e *onCallCalendarObject, included []onCallIncluded) diag.Diagnostics {
+	var outRotation onCallRotation
+	if err, ok := resourceRead(ctx, meta, fmt.Sprintf("/api/v2/on-calls/%s/rotation", url.PathEscape(e.ID)), &outRotation); err != nil {
+		return err
+	} else if !ok {
+		return onCallCalendarCopyAttrs(d, &e.Attributes, e.Relationships, included, nil)
+	}
+	return onCallCalendarCopyAttrs(d, &e.Attributes, e.Relationships, included, &outRotation)
 }
@@ -2,10 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -37,61 +33,21 @@ func newPolicyDataSource() *schema.Resource {
 	}
 }
 
-type policiesPageHTTPResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Attributes policy `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func policyLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*policiesPageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v3/policies?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr policiesPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	name := d.Get("name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range apiList[policy](ctx, uptimeAPI(meta), "/api/v3/policies") {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if e.Attributes.Name != nil && *e.Attributes.Name == name {
-				if d.Id() != "" {
-					return diag.Errorf("There are multiple policies with the same name: %s", name)
-				}
-				d.SetId(e.ID)
-				if derr := policyCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if e.Attributes.Name != nil && *e.Attributes.Name == name {
+			if d.Id() != "" {
+				return diag.Errorf("There are multiple policies with the same name: %s", name)
+			}
+			d.SetId(e.ID)
+			if derr := policyCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
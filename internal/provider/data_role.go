@@ -2,10 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,16 +25,6 @@ type role struct {
 	Role *string `json:"role,omitempty"`
 }
 
-type rolesPageHTTPResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Attributes role   `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		Next string `json:"next"`
-	} `json:"pagination"`
-}
-
 func newRoleDataSource() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: roleLookup,
@@ -71,35 +57,15 @@ func roleLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 // the id + attributes of the role whose `role` value (the system-role identifier,
 // or "custom") or display name matches the argument.
 func findRoleByName(ctx context.Context, meta interface{}, name string) (string, *role, error) {
-	baseURL := meta.(*client).BetterStackBaseURL()
-	page := 1
-	for {
-		res, err := meta.(*client).GetWithBaseURL(ctx, baseURL, fmt.Sprintf("/api/v2/roles?page=%d", page))
+	for e, err := range apiList[role](ctx, betterStackAPI(meta), "/api/v2/roles") {
 		if err != nil {
 			return "", nil, err
 		}
-		body, readErr := io.ReadAll(res.Body)
-		_ = res.Body.Close()
-		if res.StatusCode != http.StatusOK {
-			return "", nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if readErr != nil {
-			return "", nil, readErr
-		}
-		var pageResp rolesPageHTTPResponse
-		if err := json.Unmarshal(body, &pageResp); err != nil {
-			return "", nil, err
-		}
-		for _, e := range pageResp.Data {
-			if (e.Attributes.Role != nil && *e.Attributes.Role == name) ||
-				(e.Attributes.Name != nil && *e.Attributes.Name == name) {
-				attrs := e.Attributes
-				return e.ID, &attrs, nil
-			}
-		}
-		if pageResp.Pagination.Next == "" {
-			return "", nil, nil
+		if (e.Attributes.Role != nil && *e.Attributes.Role == name) ||
+			(e.Attributes.Name != nil && *e.Attributes.Name == name) {
+			attrs := e.Attributes
+			return e.ID, &attrs, nil
 		}
-		page++
 	}
+	return "", nil, nil
 }
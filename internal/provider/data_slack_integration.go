@@ -2,10 +2,6 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -39,61 +35,21 @@ func newSlackIntegrationDataSource() *schema.Resource {
 	}
 }
 
-type slackIntegrationsPageHTTPResponse struct {
-	Data []struct {
-		ID         string           `json:"id"`
-		Attributes slackIntegration `json:"attributes"`
-	} `json:"data"`
-	Pagination struct {
-		First string `json:"first"`
-		Last  string `json:"last"`
-		Prev  string `json:"prev"`
-		Next  string `json:"next"`
-	} `json:"pagination"`
-}
-
 func slackIntegrationLookup(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	fetch := func(page int) (*slackIntegrationsPageHTTPResponse, error) {
-		res, err := meta.(*client).Get(ctx, fmt.Sprintf("/api/v2/slack-integrations?page=%d", page))
-		if err != nil {
-			return nil, err
-		}
-		defer func() {
-			// Keep-Alive.
-			_, _ = io.Copy(io.Discard, res.Body)
-			_ = res.Body.Close()
-		}()
-		body, err := io.ReadAll(res.Body)
-		if res.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-		}
-		if err != nil {
-			return nil, err
-		}
-		var tr slackIntegrationsPageHTTPResponse
-		return &tr, json.Unmarshal(body, &tr)
-	}
 	slackChannelName := d.Get("slack_channel_name").(string)
-	page := 1
-	for {
-		res, err := fetch(page)
+	for e, err := range apiList[slackIntegration](ctx, uptimeAPI(meta), "/api/v2/slack-integrations") {
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		for _, e := range res.Data {
-			if e.Attributes.SlackChannelName != nil && *e.Attributes.SlackChannelName == slackChannelName {
-				if d.Id() != "" {
-					return diag.Errorf("There are multiple Slack integrations with the same slack_channel_name: %s", slackChannelName)
-				}
-				d.SetId(e.ID)
-				if derr := slackIntegrationCopyAttrs(d, &e.Attributes); derr != nil {
-					return derr
-				}
+		if e.Attributes.SlackChannelName != nil && *e.Attributes.SlackChannelName == slackChannelName {
+			if d.Id() != "" {
+				return diag.Errorf("There are multiple Slack integrations with the same slack_channel_name: %s", slackChannelName)
+			}
+			d.SetId(e.ID)
+			if derr := slackIntegrationCopyAttrs(d, &e.Attributes); derr != nil {
+				return derr
 			}
-		}
-		page++
-		if res.Pagination.Next == "" {
-			return nil
 		}
 	}
+	return nil
 }
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func defaultMetadataSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The metadata from the provider's `default_metadata` attached to this object. Keys already set on the object, e.g. by a `betteruptime_metadata` resource, keep their value and are listed with it.",
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// applyDefaultMetadata is a CustomizeDiff function planning the provider's default_metadata keys
+// the object doesn't have yet. Keys it already has keep their current value, so that explicit
+// betteruptime_metadata resources for the same owner take precedence over the defaults.
+func applyDefaultMetadata(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	c, ok := meta.(*client)
+	if !ok {
+		return nil
+	}
+	current := diff.Get("default_metadata").(map[string]interface{})
+	planned := make(map[string]interface{}, len(c.defaultMetadata))
+	for k, v := range c.defaultMetadata {
+		if existing, ok := current[k]; ok && diff.Id() != "" {
+			planned[k] = existing
+		} else {
+			planned[k] = v
+		}
+	}
+	if diff.Id() != "" && reflect.DeepEqual(planned, current) {
+		return nil
+	}
+	return diff.SetNew("default_metadata", planned)
+}
+
+// writeDefaultMetadata writes the planned default_metadata keys the object doesn't have yet (see
+// applyDefaultMetadata) via the metadata API. Keys removed from the provider's default_metadata are
+// no longer tracked, but stay on the object.
+func writeDefaultMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}, ownerType string) diag.Diagnostics {
+	old, new := d.GetChange("default_metadata")
+	existing, planned := old.(map[string]interface{}), new.(map[string]interface{})
+	keys := make([]string, 0, len(planned))
+	for k := range planned {
+		if _, ok := existing[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	ownerID := d.Id()
+	written := make(map[string]interface{}, len(planned))
+	for k, v := range existing {
+		if _, ok := planned[k]; ok {
+			written[k] = v
+		}
+	}
+	for _, k := range keys {
+		key := k
+		value := planned[k].(string)
+		in := metadata{
+			OwnerType: &ownerType,
+			OwnerID:   &ownerID,
+			Key:       &key,
+			Values:    &[]metadataValue{{Type: "String", Value: &value}},
+		}
+		var out metadataHTTPResponse
+		if derr := metadataPost(ctx, meta, &in, &out); derr != nil {
+			// The object itself was saved; only track what was written, so that the next plan retries
+			// the rest instead of replacing the object.
+			if err := d.Set("default_metadata", written); err != nil {
+				return diag.FromErr(err)
+			}
+			diags := diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Failed to attach default metadata %q", key),
+				Detail:   fmt.Sprintf("The %s was saved, but writing the provider's default_metadata failed. It will be retried on the next apply.", ownerType),
+			}}
+			for _, e := range derr {
+				e.Severity = diag.Warning
+				diags = append(diags, e)
+			}
+			return diags
+		}
+		written[k] = value
+	}
+	return nil
+}
+
+// readDefaultMetadata refreshes default_metadata from the metadata API. Keys removed from the object
+// are dropped, so that the next plan adds them again. This takes an extra request per object, so
+// nothing is fetched when the provider has no default metadata: the keys still tracked are dropped
+// by the next plan anyway, see applyDefaultMetadata.
+func readDefaultMetadata(ctx context.Context, d *schema.ResourceData, meta interface{}, ownerType string) diag.Diagnostics {
+	c := meta.(*client)
+	if len(c.defaultMetadata) == 0 {
+		return nil
+	}
+	tracked := d.Get("default_metadata").(map[string]interface{})
+	path := fmt.Sprintf("/api/v3/metadata?owner_id=%s&owner_type=%s", url.QueryEscape(d.Id()), url.QueryEscape(ownerType))
+	values := map[string]string{}
+	for e, err := range apiList[metadata](ctx, uptimeAPI(meta), path) {
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if e.Attributes.Key == nil || e.Attributes.Values == nil || len(*e.Attributes.Values) != 1 {
+			continue
+		}
+		if v := (*e.Attributes.Values)[0]; v.Type == "String" && v.Value != nil {
+			values[*e.Attributes.Key] = *v.Value
+		}
+	}
+
+	refreshed := map[string]interface{}{}
+	for k := range tracked {
+		if v, ok := values[k]; ok {
+			refreshed[k] = v
+		}
+	}
+	for k := range c.defaultMetadata {
+		if v, ok := values[k]; ok {
+			refreshed[k] = v
+		}
+	}
+	return diag.FromErr(d.Set("default_metadata", refreshed))
+}
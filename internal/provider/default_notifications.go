@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// notificationAttributes are the notification settings shared by monitors, heartbeats, incoming
+// webhooks, email integrations and the alerting integrations, which the provider's
+// default_notifications block can set defaults for.
+var notificationAttributes = []string{"call", "sms", "email", "push", "critical_alert", "team_wait", "recovery_period"}
+
+func defaultNotificationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Notification settings used by monitors, heartbeats, incoming webhooks, email integrations and alerting integrations (AWS CloudWatch, Azure, Datadog, Google Monitoring, New Relic, Grafana, Elastic and Prometheus) that don't set them. Each resource lists the attributes that came from here in `default_notifications_applied`.",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"call": {
+					Description: "Whether to call when a new incident is created.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"sms": {
+					Description: "Whether to send an SMS when a new incident is created.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"email": {
+					Description: "Whether to send an email when a new incident is created.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"push": {
+					Description: "Whether to send a push notification when a new incident is created.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"critical_alert": {
+					Description: "Whether to send a critical push notification that ignores the mute switch and Do not Disturb mode when a new incident is created.",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"team_wait": {
+					Description: "How long to wait before escalating the incident alert to the team. In seconds.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+				"recovery_period": {
+					Description: "How long the monitored service must be up to automatically mark an incident as resolved. In seconds. Not used by heartbeats.",
+					Type:        schema.TypeInt,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// loadDefaultNotifications returns the settings of the provider's default_notifications block that
+// are set explicitly. The raw config is used to tell a value set to false or 0 from an unset one,
+// when available.
+func loadDefaultNotifications(r *schema.ResourceData) map[string]interface{} {
+	defaults := map[string]interface{}{}
+	config := r.GetRawConfig()
+	if config.IsNull() {
+		for _, k := range notificationAttributes {
+			if v, ok := r.GetOkExists("default_notifications.0." + k); ok {
+				defaults[k] = v
+			}
+		}
+		return defaults
+	}
+	if !config.IsKnown() {
+		return defaults
+	}
+	block := config.GetAttr("default_notifications")
+	if block.IsNull() || !block.IsKnown() || block.LengthInt() == 0 {
+		return defaults
+	}
+	values := block.Index(cty.NumberIntVal(0))
+	for _, k := range notificationAttributes {
+		if v := values.GetAttr(k); !v.IsNull() && v.IsKnown() {
+			defaults[k] = r.Get("default_notifications.0." + k)
+		}
+	}
+	return defaults
+}
+
+func defaultNotificationsAppliedSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The notification settings (e.g. `call`) that aren't set on this resource and are taken from the provider's `default_notifications` block.",
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// applyDefaultNotifications is a CustomizeDiff function filling the notification settings that
+// aren't set in the configuration from the provider's default_notifications, so that the plan shows
+// the values that will be used. The names of the settings filled are recorded in
+// default_notifications_applied.
+func applyDefaultNotifications(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	c, ok := meta.(*client)
+	if !ok {
+		return nil
+	}
+	config := diff.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	var applied []string
+	for _, k := range notificationAttributes {
+		v, ok := c.defaultNotifications[k]
+		if !ok || !config.Type().HasAttribute(k) || !config.GetAttr(k).IsNull() {
+			continue
+		}
+		applied = append(applied, k)
+		if diff.Get(k) != v || diff.Id() == "" {
+			if err := diff.SetNew(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	sort.Strings(applied)
+
+	old := diff.Get("default_notifications_applied").(*schema.Set)
+	if diff.Id() != "" && len(applied) == old.Len() {
+		same := true
+		for _, k := range applied {
+			same = same && old.Contains(k)
+		}
+		if same {
+			return nil
+		}
+	}
+	return diff.SetNew("default_notifications_applied", applied)
+}
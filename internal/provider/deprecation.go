@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deprecatedAttributes are the deprecated attributes warned about by deprecatedAttributeWarning.
+// They don't set Deprecated, as the SDK would then raise a second warning without the replacement.
+var deprecatedAttributes = map[*schema.Schema]bool{
+	metadataSchema["value"]:             true,
+	policyStepSchema["metadata_values"]: true,
+	policyStepMemberSchema["team_id"]:   true,
+	statusPageResourceSchema["history"]: true,
+}
+
+// deprecatedAttributeWarning returns a warning that attribute at path is deprecated in favour of
+// use, suggesting the HCL to replace it with when the configured value is known, so that the plan
+// shows how to migrate the configuration. It takes the place of the warning the SDK raises for
+// attributes setting Deprecated.
+func deprecatedAttributeWarning(path cty.Path, attribute, use string, replacement ...string) diag.Diagnostic {
+	detail := fmt.Sprintf("%s is deprecated and will be removed in a future version, use %s instead.", attribute, use)
+	if len(replacement) > 0 {
+		detail += " Replace it with:\n\n" + strings.Join(replacement, "\n")
+	}
+	return diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       "Argument is deprecated",
+		Detail:        detail,
+		AttributePath: path,
+	}
+}
+
+// configSet reports whether the attribute k of the raw configuration v is set, even if its value
+// isn't known yet.
+func configSet(v cty.Value, k string) bool {
+	if v.IsNull() || !v.IsKnown() || !v.Type().IsObjectType() || !v.Type().HasAttribute(k) {
+		return false
+	}
+	return !v.GetAttr(k).IsNull()
+}
+
+// configAttr returns the attribute k of the raw configuration v if v is a known object and the
+// attribute is known and not null.
+func configAttr(v cty.Value, k string) (cty.Value, bool) {
+	if !configSet(v, k) {
+		return cty.NilVal, false
+	}
+	a := v.GetAttr(k)
+	return a, a.IsKnown()
+}
+
+// configList returns the elements of the list attribute k of the raw configuration v.
+func configList(v cty.Value, k string) []cty.Value {
+	a, ok := configAttr(v, k)
+	if !ok || !a.CanIterateElements() {
+		return nil
+	}
+	return a.AsValueSlice()
+}
+
+// upgradeList returns the elements of the list k of a raw state being upgraded, which are objects.
+func upgradeList(rawState map[string]interface{}, k string) []map[string]interface{} {
+	l, _ := rawState[k].([]interface{})
+	out := make([]map[string]interface{}, 0, len(l))
+	for _, e := range l {
+		if m, ok := e.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestStateUpgradersV0(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		upgrade  schema.StateUpgradeFunc
+		state    map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name:    "metadata value",
+			upgrade: metadataStateUpgradeV0,
+			state:   map[string]interface{}{"key": "env", "value": "production", "metadata_value": []interface{}{}},
+			expected: map[string]interface{}{"key": "env", "value": "production", "metadata_value": []interface{}{
+				map[string]interface{}{"type": "String", "value": "production", "item_id": "", "name": "", "email": ""},
+			}},
+		},
+		{
+			name:    "metadata value already in metadata_value",
+			upgrade: metadataStateUpgradeV0,
+			state: map[string]interface{}{"key": "env", "value": "production", "metadata_value": []interface{}{
+				map[string]interface{}{"type": "String", "value": "staging"},
+			}},
+			expected: map[string]interface{}{"key": "env", "value": "production", "metadata_value": []interface{}{
+				map[string]interface{}{"type": "String", "value": "staging"},
+			}},
+		},
+		{
+			name:    "policy metadata_values and team_id",
+			upgrade: policyStateUpgradeV0,
+			state: map[string]interface{}{"steps": []interface{}{
+				map[string]interface{}{
+					"type":            "metadata_branching",
+					"metadata_values": []interface{}{"eu", "us"},
+					"metadata_value":  []interface{}{},
+				},
+				map[string]interface{}{
+					"type": "escalation",
+					"step_members": []interface{}{
+						map[string]interface{}{"type": "entire_team", "id": float64(0), "team_id": float64(42)},
+						map[string]interface{}{"type": "user", "id": float64(7), "team_id": float64(0)},
+					},
+				},
+			}},
+			expected: map[string]interface{}{"steps": []interface{}{
+				map[string]interface{}{
+					"type":            "metadata_branching",
+					"metadata_values": []interface{}{"eu", "us"},
+					"metadata_value": []interface{}{
+						map[string]interface{}{"type": "String", "value": "eu", "item_id": "", "name": "", "email": ""},
+						map[string]interface{}{"type": "String", "value": "us", "item_id": "", "name": "", "email": ""},
+					},
+				},
+				map[string]interface{}{
+					"type": "escalation",
+					"step_members": []interface{}{
+						map[string]interface{}{"type": "entire_team", "id": float64(42), "team_id": float64(42)},
+						map[string]interface{}{"type": "user", "id": float64(7), "team_id": float64(0)},
+					},
+				},
+			}},
+		},
+		{
+			name:    "policy team step member",
+			upgrade: policyStateUpgradeV0,
+			state: map[string]interface{}{"steps": []interface{}{
+				map[string]interface{}{"type": "escalation", "step_members": []interface{}{
+					map[string]interface{}{"type": "entire_team", "team_id": float64(42)},
+				}},
+			}},
+			expected: map[string]interface{}{"steps": []interface{}{
+				map[string]interface{}{"type": "escalation", "step_members": []interface{}{
+					map[string]interface{}{"type": "entire_team", "id": float64(42), "team_id": float64(42)},
+				}},
+			}},
+		},
+		{
+			name:     "status page resource history",
+			upgrade:  statusPageResourceStateUpgradeV0,
+			state:    map[string]interface{}{"history": true},
+			expected: map[string]interface{}{"history": true, "widget_type": "history"},
+		},
+		{
+			name:     "status page resource with widget_type",
+			upgrade:  statusPageResourceStateUpgradeV0,
+			state:    map[string]interface{}{"history": false, "widget_type": "response_times"},
+			expected: map[string]interface{}{"history": false, "widget_type": "response_times"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			upgraded, err := tc.upgrade(context.Background(), tc.state, nil)
+			if err != nil {
+				t.Fatalf("Upgrade failed: %v", err)
+			}
+			if !reflect.DeepEqual(upgraded, tc.expected) {
+				t.Errorf("Expected %#v, got %#v", tc.expected, upgraded)
+			}
+		})
+	}
+}
+
+func TestPolicyUpgradedTeamIDDiffSuppressed(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, policySchema, map[string]interface{}{
+		"name": "Primary",
+		"steps": []interface{}{map[string]interface{}{
+			"type":         "escalation",
+			"step_members": []interface{}{map[string]interface{}{"type": "entire_team", "team_id": 42}},
+		}},
+	})
+	suppress := policyStepMemberSchema["id"].DiffSuppressFunc
+	if !suppress("steps.0.step_members.0.id", "42", "0", d) {
+		t.Error("Expected the team_id copied into id by the upgrade not to show as a diff")
+	}
+	if suppress("steps.0.step_members.0.id", "7", "0", d) {
+		t.Error("Expected a different id to show as a diff")
+	}
+}
+
+func TestDeprecationWarnings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		validate schema.ValidateRawResourceConfigFunc
+		config   cty.Value
+		expected []string
+	}{
+		{
+			name:     "metadata value",
+			validate: validateMetadataDeprecations,
+			config:   cty.ObjectVal(map[string]cty.Value{"value": cty.StringVal("production")}),
+			expected: []string{"value: metadata_value {\n  value = \"production\"\n}"},
+		},
+		{
+			name:     "metadata_value",
+			validate: validateMetadataDeprecations,
+			config:   cty.ObjectVal(map[string]cty.Value{"value": cty.NullVal(cty.String)}),
+		},
+		{
+			name:     "unknown value",
+			validate: validateMetadataDeprecations,
+			config:   cty.ObjectVal(map[string]cty.Value{"value": cty.UnknownVal(cty.String)}),
+			expected: []string{"value: "},
+		},
+		{
+			name:     "policy",
+			validate: validatePolicyDeprecations,
+			config: cty.ObjectVal(map[string]cty.Value{"steps": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"metadata_values": cty.ListVal([]cty.Value{cty.StringVal("eu"), cty.StringVal("us")}),
+					"step_members":    cty.ListValEmpty(cty.Object(map[string]cty.Type{"team_id": cty.Number})),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"metadata_values": cty.NullVal(cty.List(cty.String)),
+					"step_members": cty.ListVal([]cty.Value{
+						cty.ObjectVal(map[string]cty.Value{"team_id": cty.NullVal(cty.Number)}),
+						cty.ObjectVal(map[string]cty.Value{"team_id": cty.NumberIntVal(42)}),
+						cty.ObjectVal(map[string]cty.Value{"team_id": cty.UnknownVal(cty.Number)}),
+					}),
+				}),
+			})}),
+			expected: []string{
+				"steps.0.metadata_values: metadata_value {\n  value = \"eu\"\n}\nmetadata_value {\n  value = \"us\"\n}",
+				"steps.1.step_members.1.team_id: id = 42",
+				"steps.1.step_members.2.team_id: ",
+			},
+		},
+		{
+			name:     "status page resource history",
+			validate: validateStatusPageResourceDeprecations,
+			config:   cty.ObjectVal(map[string]cty.Value{"history": cty.True, "widget_type": cty.NullVal(cty.String)}),
+			expected: []string{"history: widget_type = \"history\""},
+		},
+		{
+			name:     "status page resource history and widget_type",
+			validate: validateStatusPageResourceDeprecations,
+			config:   cty.ObjectVal(map[string]cty.Value{"history": cty.False, "widget_type": cty.StringVal("response_times")}),
+			expected: []string{"history: widget_type = \"response_times\""},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := &schema.ValidateResourceConfigFuncResponse{}
+			tc.validate(context.Background(), schema.ValidateResourceConfigFuncRequest{RawConfig: tc.config}, resp)
+			var warnings []string
+			for _, d := range resp.Diagnostics {
+				if d.Summary != "Argument is deprecated" {
+					t.Errorf("Unexpected summary %q", d.Summary)
+				}
+				_, replacement, _ := strings.Cut(d.Detail, "Replace it with:\n\n")
+				warnings = append(warnings, pathString(d.AttributePath)+": "+replacement)
+			}
+			if !reflect.DeepEqual(warnings, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, warnings)
+			}
+		})
+	}
+}
+
+func pathString(path cty.Path) string {
+	var parts []string
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			parts = append(parts, s.Name)
+		case cty.IndexStep:
+			parts = append(parts, s.Key.AsBigFloat().Text('f', 0))
+		}
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// secretEphemeralResource is an ephemeral resource reading a single secret attribute of an API
+// object, so that it can be passed on without being stored in the state.
+type secretEphemeralResource[T any] struct {
+	client *client
+	// name is the type name of the ephemeral resource without the provider prefix.
+	name        string
+	description string
+	// idAttribute identifies the object, which is read from path (a format string taking the ID).
+	idAttribute string
+	idDesc      string
+	path        string
+	// secretAttribute holds the value returned by secret. It's also the name of the attribute in the
+	// API response, so that it's redacted from logs.
+	secretAttribute string
+	secretDesc      string
+	secret          func(*T) *string
+}
+
+var _ ephemeral.EphemeralResourceWithConfigure = (*secretEphemeralResource[policy])(nil)
+
+func (r *secretEphemeralResource[T]) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.name
+}
+
+func (r *secretEphemeralResource[T]) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: r.description,
+		Attributes: map[string]schema.Attribute{
+			r.idAttribute: schema.StringAttribute{
+				Description: r.idDesc,
+				Required:    true,
+			},
+			r.secretAttribute: schema.StringAttribute{
+				Description: r.secretDesc,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (r *secretEphemeralResource[T]) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client, got %T. This is a bug in the provider.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *secretEphemeralResource[T]) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	if r.client == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider must be configured to open this ephemeral resource.")
+		return
+	}
+	var id types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(r.idAttribute), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = context.WithValue(ctx, sensitiveKeysKey{}, map[string]bool{r.secretAttribute: true})
+	var out apiDocument[T]
+	status, err := apiDo(ctx, uptimeAPI(r.client), http.MethodGet, fmt.Sprintf(r.path, url.PathEscape(id.ValueString())), nil, &out, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Failed to read %s", r.name), err.Error())
+		return
+	}
+	if status == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root(r.idAttribute), "Not found", fmt.Sprintf("No object with ID %q exists.", id.ValueString()))
+		return
+	}
+	resp.Diagnostics.Append(resp.Result.SetAttribute(ctx, path.Root(r.idAttribute), id)...)
+	resp.Diagnostics.Append(resp.Result.SetAttribute(ctx, path.Root(r.secretAttribute), types.StringPointerValue(r.secret(&out.Data.Attributes)))...)
+}
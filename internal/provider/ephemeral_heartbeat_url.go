@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+func newHeartbeatURLEphemeralResource() ephemeral.EphemeralResource {
+	return &secretEphemeralResource[heartbeat]{
+		name:            "heartbeat_url",
+		description:     "The URL of a heartbeat, read without storing it in the state, e.g. to pass it to a write-only argument. https://betterstack.com/docs/uptime/api/heartbeats/",
+		idAttribute:     "heartbeat_id",
+		idDesc:          "The ID of the heartbeat.",
+		path:            "/api/v2/heartbeats/%s",
+		secretAttribute: "url",
+		secretDesc:      "The URL to ping the heartbeat with.",
+		secret:          func(h *heartbeat) *string { return h.Url },
+	}
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+func newIncomingWebhookURLEphemeralResource() ephemeral.EphemeralResource {
+	return &secretEphemeralResource[incomingWebhook]{
+		name:            "incoming_webhook_url",
+		description:     "The URL of an incoming webhook, read without storing it in the state, e.g. to pass it to a write-only argument. https://betterstack.com/docs/uptime/api/list-all-incoming-webhooks/",
+		idAttribute:     "incoming_webhook_id",
+		idDesc:          "The ID of the incoming webhook.",
+		path:            "/api/v2/incoming-webhooks/%s",
+		secretAttribute: "url",
+		secretDesc:      "The url at which we expect to receive the webhook.",
+		secret:          func(w *incomingWebhook) *string { return w.Url },
+	}
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+)
+
+func newPolicyIncidentTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &secretEphemeralResource[policy]{
+		name:            "policy_incident_token",
+		description:     "The incident token of an escalation policy, read without storing it in the state, e.g. to pass it to a write-only argument. https://betterstack.com/docs/uptime/api/policies/",
+		idAttribute:     "policy_id",
+		idDesc:          "The ID of the escalation policy.",
+		path:            "/api/v3/policies/%s",
+		secretAttribute: "incident_token",
+		secretDesc:      "Incident token that can be used for manually reporting incidents.",
+		secret:          func(p *policy) *string { return p.IncidentToken },
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPolicyIncidentTokenEphemeralResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v3/policies/1":
+			_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"Policy","incident_token":"secret-token"}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	r := newPolicyIncidentTokenEphemeralResource().(ephemeral.EphemeralResourceWithConfigure)
+	r.Configure(ctx, ephemeral.ConfigureRequest{ProviderData: c}, &ephemeral.ConfigureResponse{})
+	var schemaResp ephemeral.SchemaResponse
+	r.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx)
+
+	open := func(id string) *ephemeral.OpenResponse {
+		config := tftypes.NewValue(typ, map[string]tftypes.Value{
+			"policy_id":      tftypes.NewValue(tftypes.String, id),
+			"incident_token": tftypes.NewValue(tftypes.String, nil),
+		})
+		resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)}}
+		r.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}, resp)
+		return resp
+	}
+
+	resp := open("1")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Open failed: %v", resp.Diagnostics)
+	}
+	var token string
+	var values map[string]tftypes.Value
+	if err := resp.Result.Raw.As(&values); err != nil {
+		t.Fatal(err)
+	}
+	if err := values["incident_token"].As(&token); err != nil || token != "secret-token" {
+		t.Errorf("Expected the incident token, got %q (%v)", token, err)
+	}
+
+	if resp := open("2"); !resp.Diagnostics.HasError() {
+		t.Error("Expected an error for a missing policy")
+	}
+}
@@ -0,0 +1,484 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// exportType is a resource type walked by Export, listed from its paginated index endpoint.
+type exportType struct {
+	// name is the type name of the resource without the provider prefix.
+	name string
+	// path is the index endpoint, a format string taking the ID of the parent for nested resources.
+	path string
+	// parent is the type of the object nested resources belong to. Their import ID is "parent/id".
+	parent string
+}
+
+// exportTypes are the resource types exported, parents before the resources nested under them.
+// Team members and metadata aren't exported, as their objects are shared with the rest of Better
+// Stack and managed by other resources respectively.
+var exportTypes = []exportType{
+	{name: "aws_cloudwatch_integration", path: "/api/v2/aws-cloudwatch-integrations"},
+	{name: "azure_integration", path: "/api/v2/azure-integrations"},
+	{name: "catalog_relation", path: "/api/v2/catalog/relations"},
+	{name: "catalog_attribute", path: "/api/v2/catalog/relations/%s/attributes", parent: "catalog_relation"},
+	{name: "catalog_record", path: "/api/v2/catalog/relations/%s/records", parent: "catalog_relation"},
+	{name: "datadog_integration", path: "/api/v2/datadog-integrations"},
+	{name: "elastic_integration", path: "/api/v2/elastic-integrations"},
+	{name: "email_integration", path: "/api/v2/email-integrations"},
+	{name: "google_monitoring_integration", path: "/api/v2/google-monitoring-integrations"},
+	{name: "grafana_integration", path: "/api/v2/grafana-integrations"},
+	{name: "heartbeat_group", path: "/api/v2/heartbeat-groups"},
+	{name: "heartbeat", path: "/api/v2/heartbeats"},
+	{name: "incoming_webhook", path: "/api/v2/incoming-webhooks"},
+	{name: "jira_integration", path: "/api/v2/jira-integrations"},
+	{name: "monitor_group", path: "/api/v2/monitor-groups"},
+	{name: "monitor", path: "/api/v2/monitors"},
+	{name: "new_relic_integration", path: "/api/v2/new-relic-integrations"},
+	{name: "on_call_calendar", path: "/api/v2/on-calls"},
+	{name: "outgoing_webhook", path: "/api/v2/outgoing-webhooks"},
+	{name: "pagerduty_integration", path: "/api/v2/pager-duty-webhooks"},
+	{name: "policy_group", path: "/api/v2/policy-groups"},
+	{name: "policy", path: "/api/v3/policies"},
+	{name: "prometheus_integration", path: "/api/v2/prometheus-integrations"},
+	{name: "severity_group", path: "/api/v2/urgency-groups"},
+	{name: "severity", path: "/api/v2/urgencies"},
+	{name: "splunk_oncall_integration", path: "/api/v2/splunk-on-calls"},
+	{name: "status_page_group", path: "/api/v2/status-page-groups"},
+	{name: "status_page", path: "/api/v2/status-pages"},
+	{name: "status_page_section", path: "/api/v2/status-pages/%s/sections", parent: "status_page"},
+	{name: "status_page_resource", path: "/api/v2/status-pages/%s/resources", parent: "status_page"},
+}
+
+// exportReferences maps attributes holding the ID of another object to its resource type, so that
+// they're exported as references when the object is exported too.
+var exportReferences = map[string]string{
+	"attribute_id":           "catalog_attribute",
+	"expiration_policy_id":   "policy",
+	"fallback_policy_id":     "policy",
+	"heartbeat_group_id":     "heartbeat_group",
+	"monitor_group_id":       "monitor_group",
+	"policy_group_id":        "policy_group",
+	"policy_id":              "policy",
+	"relation_id":            "catalog_relation",
+	"severity_group_id":      "severity_group",
+	"status_page_group_id":   "status_page_group",
+	"status_page_id":         "status_page",
+	"status_page_section_id": "status_page_section",
+}
+
+// exportLabels are the attributes the names of exported resources are derived from, in order of
+// preference.
+var exportLabels = []string{"name", "pronounceable_name", "company_name", "public_name", "subdomain", "url"}
+
+var exportNameInvalid = regexp.MustCompile(`[^a-z0-9]+`)
+
+type exportObject struct {
+	typ      string
+	name     string
+	importID string
+	d        *schema.ResourceData
+}
+
+// Export writes the configuration of all objects accessible with the API token to dir, one
+// betteruptime_<type>.tf file per resource type, with a resource block and an import block for each
+// object. The provider is configured from the environment like an empty provider block, in
+// read-only mode. It fails if dir already has betteruptime_*.tf files. It returns the number of
+// objects exported.
+func Export(ctx context.Context, dir string, opts ...Option) (int, error) {
+	existing, err := filepath.Glob(filepath.Join(dir, "betteruptime_*.tf"))
+	if err != nil {
+		return 0, err
+	}
+	if len(existing) > 0 {
+		return 0, fmt.Errorf("%s already exists: %w", existing[0], fs.ErrExist)
+	}
+	p := New(opts...)
+	if err := diagnosticsError(p.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{"read_only": true}))); err != nil {
+		return 0, err
+	}
+	meta := p.Meta()
+
+	var objects []*exportObject
+	// names maps "type/id" of each exported object to its resource name.
+	names := map[string]string{}
+	used := map[string]bool{}
+	for _, t := range exportTypes {
+		r := p.ResourcesMap["betteruptime_"+t.name]
+		parents := []string{""}
+		if t.parent != "" {
+			parents = nil
+			for _, o := range objects {
+				if o.typ == t.parent {
+					parents = append(parents, o.d.Id())
+				}
+			}
+		}
+		for _, parent := range parents {
+			path := t.path
+			if parent != "" {
+				path = fmt.Sprintf(t.path, parent)
+			}
+			for e, err := range apiList[struct{}](ctx, uptimeAPI(meta), path) {
+				if err != nil {
+					return 0, fmt.Errorf("failed to list %s: %w", t.name, err)
+				}
+				importID := e.ID
+				if parent != "" {
+					importID = parent + "/" + e.ID
+				}
+				d, err := exportRead(ctx, r, importID, meta)
+				if err != nil {
+					return 0, fmt.Errorf("failed to read %s %s: %w", t.name, importID, err)
+				}
+				if d == nil {
+					continue
+				}
+				o := &exportObject{typ: t.name, name: exportName(t.name, r, d, used), importID: importID, d: d}
+				objects = append(objects, o)
+				names[t.name+"/"+d.Id()] = o.name
+			}
+		}
+	}
+
+	files := map[string]*hclwrite.File{}
+	usedVariables := map[string]bool{}
+	for _, o := range objects {
+		f, ok := files[o.typ]
+		if !ok {
+			f = hclwrite.NewEmptyFile()
+			files[o.typ] = f
+		} else {
+			f.Body().AppendNewline()
+		}
+		address := hcl.Traversal{hcl.TraverseRoot{Name: "betteruptime_" + o.typ}, hcl.TraverseAttr{Name: o.name}}
+		block := hclwrite.NewBlock("resource", []string{"betteruptime_" + o.typ, o.name})
+		r := p.ResourcesMap["betteruptime_"+o.typ]
+		values := map[string]interface{}{}
+		for k := range r.Schema {
+			values[k] = o.d.Get(k)
+		}
+		vars := &exportVariables{prefix: o.typ + "_" + o.name, used: usedVariables}
+		exportBody(block.Body(), r.Schema, values, names, vars, "")
+		for _, v := range vars.declared {
+			variable := f.Body().AppendNewBlock("variable", []string{v.name}).Body()
+			variable.SetAttributeValue("description", cty.StringVal(fmt.Sprintf("The sensitive %s of betteruptime_%s.%s, which isn't exported.", v.attribute, o.typ, o.name)))
+			if typ := exportVariableType(v.schema); typ != nil {
+				variable.SetAttributeRaw("type", typ)
+			}
+			variable.SetAttributeValue("sensitive", cty.True)
+			f.Body().AppendNewline()
+		}
+		f.Body().AppendBlock(block)
+		f.Body().AppendNewline()
+		imp := f.Body().AppendNewBlock("import", nil).Body()
+		imp.SetAttributeTraversal("to", address)
+		imp.SetAttributeValue("id", cty.StringVal(o.importID))
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	for typ, f := range files {
+		if err := writeNewFile(filepath.Join(dir, "betteruptime_"+typ+".tf"), f.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+	return len(objects), nil
+}
+
+// exportRead imports and reads the object with the import ID like `terraform import` does. It
+// returns nil if the object no longer exists.
+func exportRead(ctx context.Context, r *schema.Resource, importID string, meta interface{}) (*schema.ResourceData, error) {
+	d := r.TestResourceData()
+	d.SetId(importID)
+	imported, err := r.Importer.StateContext(ctx, d, meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(imported) == 0 {
+		return nil, errors.New("nothing was imported")
+	}
+	d = imported[0]
+	if err := diagnosticsError(r.ReadContext(ctx, d, meta)); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// exportName returns a unique resource name for the object, derived from its first non-empty label.
+func exportName(typ string, r *schema.Resource, d *schema.ResourceData, used map[string]bool) string {
+	name := typ + "_" + d.Id()
+	for _, k := range exportLabels {
+		if _, ok := r.Schema[k]; !ok {
+			continue
+		}
+		if v, ok := d.Get(k).(string); ok && v != "" {
+			name = strings.Trim(exportNameInvalid.ReplaceAllString(strings.ToLower(v), "_"), "_")
+			break
+		}
+	}
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = typ + "_" + name
+	}
+	unique := name
+	for i := 2; used[typ+"/"+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	used[typ+"/"+unique] = true
+	return unique
+}
+
+// exportVariables declares a variable for each sensitive value of an object, which is referenced
+// instead of writing the value to the configuration.
+type exportVariables struct {
+	// prefix starts the names of the variables, e.g. monitor_example.
+	prefix string
+	// used holds the names of the variables declared for all objects, which share one namespace.
+	used     map[string]bool
+	declared []exportVariable
+}
+
+type exportVariable struct {
+	name      string
+	attribute string
+	schema    *schema.Schema
+}
+
+// declare declares a variable for the sensitive attribute a at path, e.g. auth_password, and
+// returns the reference to it.
+func (e *exportVariables) declare(path string, a *schema.Schema) hcl.Traversal {
+	name := e.prefix + "_" + strings.ReplaceAll(path, ".", "_")
+	unique := name
+	for i := 2; e.used[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	e.used[unique] = true
+	e.declared = append(e.declared, exportVariable{name: unique, attribute: path, schema: a})
+	return hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: unique}}
+}
+
+// exportVariableType returns the type constraint of a variable for the value of a, or nil if it
+// can't be expressed.
+func exportVariableType(a *schema.Schema) hclwrite.Tokens {
+	switch a.Type {
+	case schema.TypeString:
+		return hclwrite.TokensForIdentifier("string")
+	case schema.TypeInt, schema.TypeFloat:
+		return hclwrite.TokensForIdentifier("number")
+	case schema.TypeBool:
+		return hclwrite.TokensForIdentifier("bool")
+	case schema.TypeMap:
+		elem, ok := a.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		if typ := exportVariableType(elem); typ != nil {
+			return hclwrite.TokensForFunctionCall("map", typ)
+		}
+	case schema.TypeList, schema.TypeSet:
+		collection := "list"
+		if a.Type == schema.TypeSet {
+			collection = "set"
+		}
+		if elem, ok := a.Elem.(*schema.Schema); ok {
+			if typ := exportVariableType(elem); typ != nil {
+				return hclwrite.TokensForFunctionCall(collection, typ)
+			}
+		}
+	}
+	return nil
+}
+
+// exportBody writes the attributes and blocks of s set in values to body, in alphabetical order.
+// Computed-only, deprecated and write-only attributes are omitted, and so are values equal to the
+// default, and attributes conflicting with one written before. Sensitive values are never written,
+// they are replaced by references to variables declared in vars. path is the path of the block
+// within the resource, e.g. "steps.".
+func exportBody(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}, names map[string]string, vars *exportVariables, path string) {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	written := map[string]bool{}
+	var blocks []string
+	for _, k := range keys {
+		a := s[k]
+		v, ok := values[k]
+		if !ok || !a.Optional && !a.Required || a.Deprecated != "" || deprecatedAttributes[a] || a.WriteOnly || strings.HasSuffix(k, "_wo_version") {
+			continue
+		}
+		if a.Default != nil && fmt.Sprint(v) == fmt.Sprint(a.Default) {
+			continue
+		}
+		// Empty values are only meaningful for numbers and booleans with a non-empty default.
+		if exportEmpty(v) && (a.Default == nil || a.Type != schema.TypeBool && a.Type != schema.TypeInt && a.Type != schema.TypeFloat) {
+			continue
+		}
+		if exportConflicts(a, written) {
+			continue
+		}
+		written[k] = true
+		if _, ok := a.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+		if a.Sensitive {
+			body.SetAttributeTraversal(k, vars.declare(path+k, a))
+			continue
+		}
+		if typ, ok := exportReferences[k]; ok {
+			if name, ok := names[typ+"/"+fmt.Sprint(v)]; ok {
+				body.SetAttributeTraversal(k, hcl.Traversal{hcl.TraverseRoot{Name: "betteruptime_" + typ}, hcl.TraverseAttr{Name: name}, hcl.TraverseAttr{Name: "id"}})
+				continue
+			}
+		}
+		body.SetAttributeValue(k, exportValue(a, v))
+	}
+	for _, k := range blocks {
+		elem := s[k].Elem.(*schema.Resource)
+		for _, item := range exportList(values[k]) {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			exportBody(body.AppendNewBlock(k, nil).Body(), elem.Schema, m, names, vars, path+k+".")
+		}
+	}
+}
+
+func exportConflicts(a *schema.Schema, written map[string]bool) bool {
+	for _, c := range a.ConflictsWith {
+		if written[c] {
+			return true
+		}
+	}
+	return false
+}
+
+func exportEmpty(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case int:
+		return x == 0
+	case float64:
+		return x == 0
+	case bool:
+		return !x
+	case map[string]interface{}:
+		return len(x) == 0
+	default:
+		return len(exportList(v)) == 0
+	}
+}
+
+func exportList(v interface{}) []interface{} {
+	switch x := v.(type) {
+	case []interface{}:
+		return x
+	case *schema.Set:
+		return x.List()
+	}
+	return nil
+}
+
+// exportValue converts the value of an attribute as returned by ResourceData.Get to cty.
+func exportValue(a *schema.Schema, v interface{}) cty.Value {
+	switch a.Type {
+	case schema.TypeString:
+		return cty.StringVal(v.(string))
+	case schema.TypeInt:
+		return cty.NumberIntVal(int64(v.(int)))
+	case schema.TypeFloat:
+		return cty.NumberFloatVal(v.(float64))
+	case schema.TypeBool:
+		return cty.BoolVal(v.(bool))
+	case schema.TypeMap:
+		elem, ok := a.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+		m := map[string]cty.Value{}
+		for k, e := range v.(map[string]interface{}) {
+			m[k] = exportValue(elem, e)
+		}
+		return cty.ObjectVal(m)
+	default:
+		elem := a.Elem.(*schema.Schema)
+		var l []cty.Value
+		for _, e := range exportList(v) {
+			l = append(l, exportValue(elem, e))
+		}
+		return cty.TupleVal(l)
+	}
+}
+
+// formatHCL formats attributes, pairs of names and values, as HCL, within a block of the given type
+// unless it's empty, e.g. to suggest the replacement of a deprecated attribute.
+func formatHCL(block string, attributes ...interface{}) string {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	if block != "" {
+		body = body.AppendNewBlock(block, nil).Body()
+	}
+	for i := 0; i+1 < len(attributes); i += 2 {
+		var v cty.Value
+		switch x := attributes[i+1].(type) {
+		case string:
+			v = cty.StringVal(x)
+		case int64:
+			v = cty.NumberIntVal(x)
+		case bool:
+			v = cty.BoolVal(x)
+		default:
+			panic(fmt.Errorf("unexpected type %T", x))
+		}
+		body.SetAttributeValue(attributes[i].(string), v)
+	}
+	return strings.TrimSpace(string(f.Bytes()))
+}
+
+// diagnosticsError returns the first error of diags, if any.
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+	return nil
+}
+
+// writeNewFile writes data to a file that must not exist yet.
+func writeNewFile(name string, data []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
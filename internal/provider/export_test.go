@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v3/policies?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"Primary"}}],"pagination":{"next":null}}`))
+		case "/api/v3/policies/1":
+			_, _ = w.Write([]byte(`{"data":{"id":"1","attributes":{"name":"Primary","repeat_count":3,"repeat_delay":60,"steps":[]}}}`))
+		case "/api/v2/monitors?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2","attributes":{"url":"https://example.com"}},{"id":"3","attributes":{"url":"https://example.com"}}],"pagination":{"next":null}}`))
+		case "/api/v2/monitors/2", "/api/v2/monitors/3":
+			_, _ = w.Write([]byte(`{"data":{"id":"2","attributes":{"url":"https://example.com","monitor_type":"status","pronounceable_name":"Example","policy_id":"1","status":"up","auth_username":"admin","auth_password":"s3cret-password","environment_variables":{"TOKEN":"s3cret-token"}}}}`))
+		default:
+			if r.Method == http.MethodGet && strings.HasSuffix(r.RequestURI, "?page=1") {
+				_, _ = w.Write([]byte(`{"data":[],"pagination":{"next":null}}`))
+				return
+			}
+			t.Errorf("Unexpected %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("BETTERUPTIME_API_TOKEN", "foo")
+
+	dir := t.TempDir()
+	n, err := Export(context.Background(), dir, WithURL(server.URL))
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Expected 3 objects, got %d", n)
+	}
+
+	monitors, err := os.ReadFile(filepath.Join(dir, "betteruptime_monitor.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`resource "betteruptime_monitor" "example" {`,
+		`resource "betteruptime_monitor" "example_2" {`,
+		`policy_id             = betteruptime_policy.primary.id`,
+		`url                   = "https://example.com"`,
+		"import {\n  to = betteruptime_monitor.example_2\n  id = \"3\"\n}",
+		`auth_password         = var.monitor_example_auth_password`,
+		`environment_variables = var.monitor_example_2_environment_variables`,
+		"variable \"monitor_example_auth_password\" {\n  description = \"The sensitive auth_password of betteruptime_monitor.example, which isn't exported.\"\n  type        = string\n  sensitive   = true\n}",
+		"variable \"monitor_example_2_environment_variables\" {\n  description = \"The sensitive environment_variables of betteruptime_monitor.example_2, which isn't exported.\"\n  type        = map(string)\n  sensitive   = true\n}",
+	} {
+		if !strings.Contains(string(monitors), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, monitors)
+		}
+	}
+	for _, unexpected := range []string{"status ", "auth_password_wo", "http_method"} {
+		if strings.Contains(string(monitors), unexpected) {
+			t.Errorf("Unexpected %q in:\n%s", unexpected, monitors)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "betteruptime_policy.tf")); err != nil {
+		t.Error(err)
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(b), "s3cret") {
+			t.Errorf("Expected sensitive values not to be exported, got:\n%s", b)
+		}
+	}
+
+	if _, err := Export(context.Background(), dir, WithURL(server.URL)); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("Expected existing files not to be overwritten, got %v", err)
+	}
+}
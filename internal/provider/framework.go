@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider is the terraform-plugin-framework part of the provider, served next to the SDK
+// provider by NewProviderServer so that resources can be ported to the framework one by one.
+//
+// Terraform requires both parts to have the same provider schema, so it's derived from the SDK
+// provider's. Both parts also share the client: the mux server configures the SDK provider first,
+// and the framework provider picks up the client it created instead of resolving the API token and
+// checking it a second time.
+type frameworkProvider struct {
+	sdk     *schema.Provider
+	version string
+}
+
+var (
+	_ fwprovider.Provider                       = (*frameworkProvider)(nil)
+	_ fwprovider.ProviderWithFunctions          = (*frameworkProvider)(nil)
+	_ fwprovider.ProviderWithEphemeralResources = (*frameworkProvider)(nil)
+	_ fwprovider.ProviderWithListResources      = (*frameworkProvider)(nil)
+)
+
+func newFrameworkProvider(sdk *schema.Provider, version string) *frameworkProvider {
+	return &frameworkProvider{sdk: sdk, version: version}
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "betteruptime"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	s, err := frameworkProviderSchema(p.sdk.Schema)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid provider schema", err.Error())
+		return
+	}
+	resp.Schema = s
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	c, ok := p.sdk.Meta().(*client)
+	if !ok {
+		resp.Diagnostics.AddError("Provider not configured", "The SDK provider must be configured before the framework provider. This is a bug in the provider.")
+		return
+	}
+	resp.ResourceData = c
+	resp.DataSourceData = c
+	resp.EphemeralResourceData = c
+	resp.ListResourceData = c
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newHeartbeatURLEphemeralResource,
+		newIncomingWebhookURLEphemeralResource,
+		newPolicyIncidentTokenEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return sdkListResources(p.sdk)
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newHeartbeatPingURLFunction,
+		newIPCIDRsFunction,
+		newNormalizeTimeFunction,
+		newNormalizeTimezoneFunction,
+	}
+}
+
+// frameworkProviderSchema converts the schema of the SDK provider into the equivalent framework
+// provider schema. Only the attribute types used by the provider configuration are supported.
+func frameworkProviderSchema(s map[string]*schema.Schema) (fwschema.Schema, error) {
+	attributes, blocks, err := frameworkProviderAttributes(s)
+	if err != nil {
+		return fwschema.Schema{}, err
+	}
+	return fwschema.Schema{Attributes: attributes, Blocks: blocks}, nil
+}
+
+func frameworkProviderAttributes(s map[string]*schema.Schema) (map[string]fwschema.Attribute, map[string]fwschema.Block, error) {
+	attributes := map[string]fwschema.Attribute{}
+	blocks := map[string]fwschema.Block{}
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := s[k]
+		switch v.Type {
+		case schema.TypeString:
+			attributes[k] = fwschema.StringAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case schema.TypeBool:
+			attributes[k] = fwschema.BoolAttribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case schema.TypeInt:
+			attributes[k] = fwschema.Int64Attribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case schema.TypeFloat:
+			attributes[k] = fwschema.Float64Attribute{Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+		case schema.TypeList, schema.TypeSet, schema.TypeMap:
+			if r, ok := v.Elem.(*schema.Resource); ok && v.Type == schema.TypeList {
+				nested, nestedBlocks, err := frameworkProviderAttributes(r.Schema)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s.%w", k, err)
+				}
+				blocks[k] = fwschema.ListNestedBlock{
+					Description:  v.Description,
+					NestedObject: fwschema.NestedBlockObject{Attributes: nested, Blocks: nestedBlocks},
+				}
+				continue
+			}
+			elem, err := frameworkElementType(v.Elem)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", k, err)
+			}
+			switch v.Type {
+			case schema.TypeList:
+				attributes[k] = fwschema.ListAttribute{ElementType: elem, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			case schema.TypeSet:
+				attributes[k] = fwschema.SetAttribute{ElementType: elem, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			default:
+				attributes[k] = fwschema.MapAttribute{ElementType: elem, Description: v.Description, Required: v.Required, Optional: v.Optional, Sensitive: v.Sensitive}
+			}
+		default:
+			return nil, nil, fmt.Errorf("%s: unsupported type %s", k, v.Type)
+		}
+	}
+	return attributes, blocks, nil
+}
+
+func frameworkElementType(elem interface{}) (attr.Type, error) {
+	s, ok := elem.(*schema.Schema)
+	if !ok {
+		// The SDK defaults to strings.
+		return types.StringType, nil
+	}
+	switch s.Type {
+	case schema.TypeString:
+		return types.StringType, nil
+	case schema.TypeBool:
+		return types.BoolType, nil
+	case schema.TypeInt:
+		return types.Int64Type, nil
+	case schema.TypeFloat:
+		return types.Float64Type, nil
+	default:
+		return nil, fmt.Errorf("unsupported element type %s", s.Type)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderServer(t *testing.T) {
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, WithURL("http://localhost"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+
+	// The mux server rejects differing provider schemas.
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range schemaResp.Diagnostics {
+		t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if schemaResp.ResourceSchemas["betteruptime_monitor"] == nil {
+		t.Error("Expected the SDK resources to be served")
+	}
+	if schemaResp.Functions["heartbeat_ping_url"] == nil {
+		t.Error("Expected the framework functions to be served")
+	}
+
+	if schemaResp.ListResourceSchemas["betteruptime_monitor"] == nil {
+		t.Error("Expected the list resources to be served")
+	}
+
+	configureProviderServer(t, server, schemaResp)
+}
+
+// configureProviderServer configures the provider server with an API token.
+func configureProviderServer(t *testing.T, server tfprotov5.ProviderServer, schemaResp *tfprotov5.GetProviderSchemaResponse) {
+	t.Helper()
+	typ := schemaResp.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for k, v := range typ.AttributeTypes {
+		values[k] = tftypes.NewValue(v, nil)
+	}
+	values["api_token"] = tftypes.NewValue(tftypes.String, "foo")
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureResp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range configureResp.Diagnostics {
+		t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+}
+
+// runFunction calls a provider function with the given arguments and returns its result.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+	var def function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &def)
+	result, err := def.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// heartbeatPingBaseURL is where heartbeats are pinged, followed by the heartbeat's token.
+const heartbeatPingBaseURL = defaultUptimeURL + "/api/v1/heartbeat/"
+
+type heartbeatPingURLFunction struct{}
+
+var _ function.Function = heartbeatPingURLFunction{}
+
+func newHeartbeatPingURLFunction() function.Function {
+	return heartbeatPingURLFunction{}
+}
+
+func (f heartbeatPingURLFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "heartbeat_ping_url"
+}
+
+func (f heartbeatPingURLFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build the URL to ping a heartbeat",
+		Description: "Returns the URL to ping a heartbeat with, optionally reporting a failure (`fail`) or the exit code of the job (e.g. `1`).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id_or_url",
+				Description: "The `url` of a `betteruptime_heartbeat`, or the token it ends with.",
+			},
+			function.StringParameter{
+				Name:        "suffix",
+				Description: "Empty to report success, `fail` to report a failure, or an exit code between 0 and 255.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f heartbeatPingURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var idOrURL, suffix string
+	resp.Error = req.Arguments.Get(ctx, &idOrURL, &suffix)
+	if resp.Error != nil {
+		return
+	}
+	base, err := heartbeatPingURL(idOrURL)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	switch suffix = strings.TrimPrefix(suffix, "/"); {
+	case suffix == "":
+	case suffix == "fail":
+		base += "/fail"
+	default:
+		code, err := strconv.Atoi(suffix)
+		if err != nil || code < 0 || code > 255 {
+			resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("suffix must be empty, \"fail\" or an exit code between 0 and 255, got %q", suffix))
+			return
+		}
+		base += "/" + strconv.Itoa(code)
+	}
+	resp.Error = resp.Result.Set(ctx, base)
+}
+
+// heartbeatPingURL returns the ping URL of a heartbeat given its URL or token, without any suffix.
+func heartbeatPingURL(idOrURL string) (string, error) {
+	idOrURL = strings.TrimSpace(idOrURL)
+	if !strings.Contains(idOrURL, "://") {
+		if idOrURL == "" || strings.ContainsAny(idOrURL, "/?#") {
+			return "", fmt.Errorf("expected a heartbeat URL or token, got %q", idOrURL)
+		}
+		return heartbeatPingBaseURL + idOrURL, nil
+	}
+	u, err := url.Parse(idOrURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("expected a heartbeat URL or token, got %q", idOrURL)
+	}
+	return strings.TrimSuffix(idOrURL, "/"), nil
+}
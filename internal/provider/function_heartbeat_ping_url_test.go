@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHeartbeatPingURLFunction(t *testing.T) {
+	tests := []struct {
+		idOrURL, suffix, expected string
+	}{
+		{"abc123", "", "https://uptime.betterstack.com/api/v1/heartbeat/abc123"},
+		{"abc123", "fail", "https://uptime.betterstack.com/api/v1/heartbeat/abc123/fail"},
+		{"https://uptime.betterstack.com/api/v1/heartbeat/abc123/", "/fail", "https://uptime.betterstack.com/api/v1/heartbeat/abc123/fail"},
+		{"https://uptime.betterstack.com/api/v1/heartbeat/abc123", "2", "https://uptime.betterstack.com/api/v1/heartbeat/abc123/2"},
+		{"abc123", "256", ""},
+		{"abc123", "failed", ""},
+		{"abc/123", "", ""},
+		{"ftp://example.com/abc123", "", ""},
+	}
+	for _, test := range tests {
+		got, err := runFunction(t, newHeartbeatPingURLFunction(), types.StringValue(test.idOrURL), types.StringValue(test.suffix))
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("heartbeat_ping_url(%q, %q): expected an error, got %s", test.idOrURL, test.suffix, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("heartbeat_ping_url(%q, %q): %v", test.idOrURL, test.suffix, err)
+		} else if !got.Equal(types.StringValue(test.expected)) {
+			t.Errorf("heartbeat_ping_url(%q, %q): expected %s, got %s", test.idOrURL, test.suffix, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type ipCIDRsFunction struct{}
+
+var _ function.Function = ipCIDRsFunction{}
+
+func newIPCIDRsFunction() function.Function {
+	return ipCIDRsFunction{}
+}
+
+func (f ipCIDRsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ip_cidrs"
+}
+
+func (f ipCIDRsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert IP addresses to CIDR blocks",
+		Description: "Returns the IP addresses as single-address CIDR blocks (`/32` for IPv4, `/128` for IPv6) in the same order, e.g. to allow the monitoring IPs of `betteruptime_ip_list` in firewall rules. CIDR blocks are kept as they are.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "ips",
+				Description: "IPv4 and IPv6 addresses, e.g. the `ips` of `betteruptime_ip_list`.",
+				ElementType: types.StringType,
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f ipCIDRsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ips []string
+	resp.Error = req.Arguments.Get(ctx, &ips)
+	if resp.Error != nil {
+		return
+	}
+	cidrs, err := ipCIDRs(ips)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, cidrs)
+}
+
+func ipCIDRs(ips []string) ([]string, error) {
+	cidrs := make([]string, len(ips))
+	for i, v := range ips {
+		v = strings.TrimSpace(v)
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR block %q", v)
+			}
+			cidrs[i] = prefix.Masked().String()
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP address %q", v)
+		}
+		addr = addr.Unmap().WithZone("")
+		cidrs[i] = netip.PrefixFrom(addr, addr.BitLen()).String()
+	}
+	return cidrs, nil
+}
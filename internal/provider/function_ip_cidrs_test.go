@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIPCIDRsFunction(t *testing.T) {
+	ips := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("192.0.2.1"),
+		types.StringValue("2001:db8::1"),
+		types.StringValue("::ffff:198.51.100.7"),
+		types.StringValue("203.0.113.5/24"),
+	})
+	expected := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("192.0.2.1/32"),
+		types.StringValue("2001:db8::1/128"),
+		types.StringValue("198.51.100.7/32"),
+		types.StringValue("203.0.113.0/24"),
+	})
+	got, err := runFunction(t, newIPCIDRsFunction(), ips)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(expected) {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	invalid := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("example.com")})
+	if got, err := runFunction(t, newIPCIDRsFunction(), invalid); err == nil {
+		t.Errorf("Expected an error for an invalid IP address, got %s", got)
+	}
+}
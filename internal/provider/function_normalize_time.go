@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// clockTimePattern matches the times of day normalize_time accepts: `9:30`, `09:30` or `09:30:00`.
+var clockTimePattern = regexp.MustCompile(`^(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+
+type normalizeTimeFunction struct{}
+
+var _ function.Function = normalizeTimeFunction{}
+
+func newNormalizeTimeFunction() function.Function {
+	return normalizeTimeFunction{}
+}
+
+func (f normalizeTimeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_time"
+}
+
+func (f normalizeTimeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Normalize a time of day to HH:MM",
+		Description: "Returns a time of day in the `HH:MM` format expected by `wait_until_time`, `time_from` and `time_to` of policy steps, e.g. `09:30` for `9:30` or `09:30:00`. Fails if it isn't a valid time of day.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "time",
+				Description: "The time of day, as `H:MM`, `HH:MM` or `HH:MM:SS` with zero seconds.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f normalizeTimeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var v string
+	resp.Error = req.Arguments.Get(ctx, &v)
+	if resp.Error != nil {
+		return
+	}
+	normalized, err := normalizeTime(v)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+func normalizeTime(v string) (string, error) {
+	m := clockTimePattern.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return "", fmt.Errorf("expected a time of day in HH:MM format, got %q", v)
+	}
+	hours, _ := strconv.Atoi(m[1])
+	minutes, _ := strconv.Atoi(m[2])
+	if hours > 23 || minutes > 59 || (m[3] != "" && m[3] != "00") {
+		return "", fmt.Errorf("expected a time of day in HH:MM format, got %q", v)
+	}
+	return fmt.Sprintf("%02d:%02d", hours, minutes), nil
+}
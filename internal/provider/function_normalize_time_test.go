@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeTimeFunction(t *testing.T) {
+	tests := map[string]string{
+		"9:30":     "09:30",
+		"09:30":    "09:30",
+		" 23:59 ":  "23:59",
+		"00:00:00": "00:00",
+		"24:00":    "",
+		"12:60":    "",
+		"12:30:15": "",
+		"12.30":    "",
+		"":         "",
+	}
+	for v, expected := range tests {
+		got, err := runFunction(t, newNormalizeTimeFunction(), types.StringValue(v))
+		if expected == "" {
+			if err == nil {
+				t.Errorf("normalize_time(%q): expected an error, got %s", v, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalize_time(%q): %v", v, err)
+		} else if !got.Equal(types.StringValue(expected)) {
+			t.Errorf("normalize_time(%q): expected %s, got %s", v, expected, got)
+		}
+	}
+}
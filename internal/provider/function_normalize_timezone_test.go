@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeTimezoneFunction(t *testing.T) {
+	tests := map[string]string{
+		"Prague":                     "Prague",
+		"eastern time (us & canada)": "Eastern Time (US & Canada)",
+		"Europe/Prague":              "Prague",
+		"Europe/London":              "London",
+		"America/New_York":           "Eastern Time (US & Canada)",
+		"Etc/UTC":                    "UTC",
+		"America/Boise":              "America/Boise",
+		"Mars/Olympus_Mons":          "",
+		"Local":                      "",
+		"":                           "",
+	}
+	for v, expected := range tests {
+		got, err := runFunction(t, newNormalizeTimezoneFunction(), types.StringValue(v))
+		if expected == "" {
+			if err == nil {
+				t.Errorf("normalize_timezone(%q): expected an error, got %s", v, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("normalize_timezone(%q): %v", v, err)
+		} else if !got.Equal(types.StringValue(expected)) {
+			t.Errorf("normalize_timezone(%q): expected %s, got %s", v, expected, got)
+		}
+	}
+
+	for _, tz := range railsTimeZones {
+		if _, err := time.LoadLocation(tz[1]); err != nil {
+			t.Errorf("%s: %v", tz[0], err)
+		}
+	}
+}
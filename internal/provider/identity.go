@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// idResourceIdentity is the resource identity of resources identified by their ID alone. Resources
+// with an identity can be listed by `terraform query` and imported by identity.
+func idResourceIdentity() *schema.ResourceIdentity {
+	return stringResourceIdentity(map[string]string{
+		"id": "The ID of the object.",
+	})
+}
+
+// nestedResourceIdentity is the resource identity of resources nested under a parent object, e.g.
+// catalog records under a relation, which need the ID of the parent besides their own.
+func nestedResourceIdentity(parent, description string) *schema.ResourceIdentity {
+	return stringResourceIdentity(map[string]string{
+		parent: description,
+		"id":   "The ID of the object.",
+	})
+}
+
+// stringResourceIdentity returns an identity with the given string attributes and descriptions.
+// Attributes other than id must be string attributes of the resource.
+func stringResourceIdentity(attributes map[string]string) *schema.ResourceIdentity {
+	return &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			s := make(map[string]*schema.Schema, len(attributes))
+			for k, description := range attributes {
+				s[k] = &schema.Schema{
+					Description:       description,
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+				}
+			}
+			return s
+		},
+	}
+}
+
+// withIdentity wraps the create, read and update functions of a resource with an identity to set
+// the identity from the ID and the other attributes, as Terraform requires it after each of them.
+// It also wraps the importer to import by identity.
+func withIdentity(r *schema.Resource) *schema.Resource {
+	if r.Identity == nil {
+		return r
+	}
+	r.CreateContext = withIdentityFunc(r.CreateContext, r)
+	r.ReadContext = withIdentityFunc(r.ReadContext, r)
+	r.UpdateContext = withIdentityFunc(r.UpdateContext, r)
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		r.Importer.StateContext = withIdentityImport(r.Importer.StateContext, r)
+	}
+	return r
+}
+
+func withIdentityFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F, r *schema.Resource) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := f(ctx, d, meta)
+		if d.Id() == "" {
+			return diags
+		}
+		return append(diags, setIdentity(d, r)...)
+	}
+}
+
+// withIdentityImport sets the ID and the other identity attributes when importing by identity, in
+// which case Terraform passes no ID. Imports by ID are left to importID.
+func withIdentityImport(importID schema.StateContextFunc, r *schema.Resource) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if d.Id() != "" {
+			return importID(ctx, d, meta)
+		}
+		identity, err := d.Identity()
+		if err != nil {
+			return nil, err
+		}
+		for k := range r.Identity.SchemaMap() {
+			v, ok := identity.GetOk(k)
+			if !ok {
+				return nil, fmt.Errorf("the identity must contain %s", k)
+			}
+			if k == "id" {
+				d.SetId(v.(string))
+			} else if err := d.Set(k, v); err != nil {
+				return nil, err
+			}
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+func setIdentity(d *schema.ResourceData, r *schema.Resource) diag.Diagnostics {
+	identity, err := d.Identity()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for k := range r.Identity.SchemaMap() {
+		v := d.Get(k)
+		if k == "id" {
+			v = d.Id()
+		}
+		if err := identity.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceIdentity(t *testing.T) {
+	p := New()
+	for name, r := range p.ResourcesMap {
+		if r.Identity == nil {
+			t.Errorf("Expected %s to have an identity", name)
+		}
+	}
+
+	ctx := context.Background()
+	r := p.ResourcesMap["betteruptime_catalog_record"]
+
+	// Import by identity.
+	d := r.TestResourceData()
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.Set("relation_id", "123"); err != nil {
+		t.Fatal(err)
+	}
+	if err := identity.Set("id", "234"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Importer.StateContext(ctx, d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "234" || d.Get("relation_id") != "123" {
+		t.Errorf("Expected ID 234 and relation_id 123, got %q and %q", d.Id(), d.Get("relation_id"))
+	}
+
+	// Import by ID still works.
+	d = r.TestResourceData()
+	d.SetId("123/234")
+	if _, err := r.Importer.StateContext(ctx, d, nil); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id() != "234" || d.Get("relation_id") != "123" {
+		t.Errorf("Expected ID 234 and relation_id 123, got %q and %q", d.Id(), d.Get("relation_id"))
+	}
+}
+
+func TestWithIdentity(t *testing.T) {
+	read := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if d.Id() == "404" {
+			d.SetId("")
+		}
+		return nil
+	}
+	r := withIdentity(&schema.Resource{
+		ReadContext: read,
+		Schema:      map[string]*schema.Schema{"status_page_id": {Type: schema.TypeString, Required: true}},
+		Identity:    nestedResourceIdentity("status_page_id", "The ID of the Status Page."),
+	})
+
+	d := r.TestResourceData()
+	d.SetId("2")
+	if err := d.Set("status_page_id", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.ReadContext(context.Background(), d, nil); diags.HasError() {
+		t.Fatal(diags)
+	}
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Get("status_page_id") != "1" || identity.Get("id") != "2" {
+		t.Errorf("Expected the identity to be set after read, got %v and %v", identity.Get("status_page_id"), identity.Get("id"))
+	}
+
+	d = r.TestResourceData()
+	d.SetId("404")
+	if diags := r.ReadContext(context.Background(), d, nil); diags.HasError() {
+		t.Errorf("Expected no identity to be set for a removed object, got %v", diags)
+	}
+}
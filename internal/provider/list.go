@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sdkListResource lists the objects of an SDK resource from its paginated index endpoint, so that
+// `terraform query` can find existing objects and generate their configuration and import blocks.
+type sdkListResource[T any] struct {
+	client *client
+	// name is the type name of the resource without the provider prefix.
+	name     string
+	resource *schema.Resource
+	path     string
+	// copyAttrs copies an object from the index endpoint into the resource data like a read of the
+	// resource does. When nil, the resource is read for each object instead.
+	copyAttrs   func(*schema.ResourceData, *T) diag.Diagnostics
+	displayName func(*T) *string
+}
+
+var (
+	_ list.ListResourceWithConfigure    = (*sdkListResource[monitor])(nil)
+	_ list.ListResourceWithRawV5Schemas = (*sdkListResource[monitor])(nil)
+)
+
+func newSDKListResource[T any](sdk *schema.Provider, name, path string, copyAttrs func(*schema.ResourceData, *T) diag.Diagnostics, displayName func(*T) *string) func() list.ListResource {
+	return func() list.ListResource {
+		return &sdkListResource[T]{
+			name:        name,
+			resource:    sdk.ResourcesMap["betteruptime_"+name],
+			path:        path,
+			copyAttrs:   copyAttrs,
+			displayName: displayName,
+		}
+	}
+}
+
+// sdkListResources returns the list resources of the SDK resources with an identity.
+func sdkListResources(sdk *schema.Provider) []func() list.ListResource {
+	copyStatusPage := func(d *schema.ResourceData, in *statusPage) diag.Diagnostics {
+		return statusPageCopyAttrs(d, in, nil)
+	}
+	return []func() list.ListResource{
+		newSDKListResource(sdk, "aws_cloudwatch_integration", "/api/v2/aws-cloudwatch-integrations", awsCloudWatchIntegrationCopyAttrs, func(in *awsCloudWatchIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "azure_integration", "/api/v2/azure-integrations", azureIntegrationCopyAttrs, func(in *azureIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "datadog_integration", "/api/v2/datadog-integrations", datadogIntegrationCopyAttrs, func(in *datadogIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "elastic_integration", "/api/v2/elastic-integrations", elasticIntegrationCopyAttrs, func(in *elasticIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "email_integration", "/api/v2/email-integrations", emailIntegrationCopyAttrs, func(in *emailIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "google_monitoring_integration", "/api/v2/google-monitoring-integrations", googleMonitoringIntegrationCopyAttrs, func(in *googleMonitoringIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "grafana_integration", "/api/v2/grafana-integrations", grafanaIntegrationCopyAttrs, func(in *grafanaIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "heartbeat", "/api/v2/heartbeats", heartbeatCopyAttrs, func(in *heartbeat) *string { return in.Name }),
+		newSDKListResource(sdk, "incoming_webhook", "/api/v2/incoming-webhooks", incomingWebhookCopyAttrs, func(in *incomingWebhook) *string { return in.Name }),
+		newSDKListResource(sdk, "jira_integration", "/api/v2/jira-integrations", jiraIntegrationCopyAttrs, func(in *jiraIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "monitor", "/api/v2/monitors", monitorCopyAttrs, func(in *monitor) *string { return in.PronounceableName }),
+		newSDKListResource(sdk, "new_relic_integration", "/api/v2/new-relic-integrations", newRelicIntegrationCopyAttrs, func(in *newRelicIntegration) *string { return in.Name }),
+		// The on-call rotation and users aren't part of the index, so calendars are read one by one.
+		newSDKListResource(sdk, "on_call_calendar", "/api/v2/on-calls", nil, func(in *onCallCalendar) *string { return in.Name }),
+		newSDKListResource(sdk, "outgoing_webhook", "/api/v2/outgoing-webhooks", outgoingWebhookCopyAttrs, func(in *outgoingWebhook) *string { return in.Name }),
+		newSDKListResource(sdk, "pagerduty_integration", "/api/v2/pager-duty-webhooks", pagerdutyIntegrationCopyAttrs, func(in *pagerdutyIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "policy", "/api/v3/policies", policyCopyAttrs, func(in *policy) *string { return in.Name }),
+		newSDKListResource(sdk, "prometheus_integration", "/api/v2/prometheus-integrations", prometheusIntegrationCopyAttrs, func(in *prometheusIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "splunk_oncall_integration", "/api/v2/splunk-on-calls", splunkOnCallIntegrationCopyAttrs, func(in *splunkOnCallIntegration) *string { return in.Name }),
+		newSDKListResource(sdk, "status_page", "/api/v2/status-pages", copyStatusPage, func(in *statusPage) *string { return in.CompanyName }),
+	}
+}
+
+func (r *sdkListResource[T]) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.name
+}
+
+func (r *sdkListResource[T]) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: fmt.Sprintf("Lists all `betteruptime_%s` objects accessible with the API token.", r.name),
+	}
+}
+
+func (r *sdkListResource[T]) RawV5Schemas(ctx context.Context, req list.RawV5SchemaRequest, resp *list.RawV5SchemaResponse) {
+	resp.ProtoV5Schema = r.resource.ProtoSchema(ctx)()
+	resp.ProtoV5IdentitySchema = r.resource.ProtoIdentitySchema(ctx)()
+}
+
+func (r *sdkListResource[T]) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected provider data", fmt.Sprintf("Expected *client, got %T. This is a bug in the provider.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+func (r *sdkListResource[T]) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	if r.client == nil {
+		var diags fwdiag.Diagnostics
+		diags.AddError("Provider not configured", "The provider must be configured to list objects.")
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	stream.Results = func(push func(list.ListResult) bool) {
+		var n int64
+		for e, err := range apiList[T](ctx, uptimeAPI(r.client), r.path) {
+			if req.Limit > 0 && n >= req.Limit {
+				return
+			}
+			if err != nil {
+				result := list.ListResult{}
+				result.Diagnostics.AddError(fmt.Sprintf("Failed to list %s", r.name), err.Error())
+				push(result)
+				return
+			}
+			n++
+			if !push(r.result(ctx, req, e)) {
+				return
+			}
+		}
+	}
+}
+
+// result returns the list result of an object, setting its state like an import followed by a read.
+func (r *sdkListResource[T]) result(ctx context.Context, req list.ListRequest, e apiObject[T]) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = e.ID
+	if name := r.displayName(&e.Attributes); name != nil && *name != "" {
+		result.DisplayName = *name
+	}
+
+	d := r.resource.TestResourceData()
+	d.SetId(e.ID)
+	result.Diagnostics.Append(frameworkDiagnostics(setIdentity(d, r.resource))...)
+	identity, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics.AddError("Failed to convert the identity", err.Error())
+		return result
+	}
+	result.Identity.Raw = *identity
+
+	if !req.IncludeResource {
+		return result
+	}
+	if r.copyAttrs != nil {
+		result.Diagnostics.Append(frameworkDiagnostics(r.copyAttrs(d, &e.Attributes))...)
+	} else {
+		result.Diagnostics.Append(frameworkDiagnostics(r.resource.ReadContext(ctx, d, r.client))...)
+	}
+	if result.Diagnostics.HasError() {
+		return result
+	}
+	state, err := d.TfTypeResourceState()
+	if err != nil {
+		result.Diagnostics.AddError("Failed to convert the state", err.Error())
+		return result
+	}
+	result.Resource.Raw = *state
+	return result
+}
+
+// frameworkDiagnostics converts SDK diagnostics to framework diagnostics.
+func frameworkDiagnostics(diags diag.Diagnostics) fwdiag.Diagnostics {
+	var out fwdiag.Diagnostics
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			out.AddError(d.Summary, d.Detail)
+		} else {
+			out.AddWarning(d.Summary, d.Detail)
+		}
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestListResource(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.RequestURI {
+		case "/api/v2/heartbeats?page=1":
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"Backup","period":60,"grace":0}}],"pagination":{"next":"/api/v2/heartbeats?page=2"}}`))
+		case "/api/v2/heartbeats?page=2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"2","attributes":{"name":"Cron","period":3600,"grace":300}},{"id":"3","attributes":{"name":"Queue","period":60,"grace":0}}],"pagination":{"next":null}}`))
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	ctx := context.Background()
+	factory, err := NewProviderServer(ctx, WithURL(api.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := factory()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configureProviderServer(t, server, schemaResp)
+
+	resourceType := schemaResp.ResourceSchemas["betteruptime_heartbeat"].ValueType()
+	identityResp, err := server.GetResourceIdentitySchemas(ctx, &tfprotov5.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityType := identityResp.IdentitySchemas["betteruptime_heartbeat"].ValueType()
+	configType := schemaResp.ListResourceSchemas["betteruptime_heartbeat"].ValueType()
+	config, err := tfprotov5.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream, err := server.(tfprotov5.ProviderServerWithListResource).ListResource(ctx, &tfprotov5.ListResourceRequest{
+		TypeName:        "betteruptime_heartbeat",
+		Config:          &config,
+		IncludeResource: true,
+		Limit:           2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names, ids []string
+	for result := range stream.Results {
+		for _, d := range result.Diagnostics {
+			t.Errorf("Unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+		names = append(names, result.DisplayName)
+
+		identity, err := result.Identity.IdentityData.Unmarshal(identityType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var identityValues map[string]tftypes.Value
+		var id string
+		if err := identity.As(&identityValues); err != nil {
+			t.Fatal(err)
+		}
+		if err := identityValues["id"].As(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+
+		state, err := result.Resource.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values map[string]tftypes.Value
+		var name string
+		if err := state.As(&values); err != nil {
+			t.Fatal(err)
+		}
+		if err := values["name"].As(&name); err != nil || name != result.DisplayName {
+			t.Errorf("Expected the state to have name %q, got %q (%v)", result.DisplayName, name, err)
+		}
+	}
+	if len(names) != 2 || names[0] != "Backup" || names[1] != "Cron" {
+		t.Errorf("Expected the first 2 heartbeats across pages, got %v", names)
+	}
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("Expected the identities to hold the IDs, got %v", ids)
+	}
+}
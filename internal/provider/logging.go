@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// redactedValue replaces the values of sensitive attributes in logged and reported API bodies.
+const redactedValue = "***"
+
+type sensitiveKeysKey struct{}
+
+// sensitiveKeys returns the names of the attributes marked Sensitive in s, including those of
+// nested blocks.
+func sensitiveKeys(s map[string]*schema.Schema) map[string]bool {
+	keys := map[string]bool{}
+	for k, v := range s {
+		if v.Sensitive {
+			keys[k] = true
+		}
+		if r, ok := v.Elem.(*schema.Resource); ok {
+			for k := range sensitiveKeys(r.Schema) {
+				keys[k] = true
+			}
+		}
+	}
+	return keys
+}
+
+// withSensitiveKeys wraps the CRUD functions of r so that API calls made by them know which
+// attributes of r are sensitive, and mask them in logs.
+func withSensitiveKeys(r *schema.Resource) *schema.Resource {
+	keys := sensitiveKeys(r.Schema)
+	if len(keys) == 0 {
+		return r
+	}
+	r.CreateContext = withSensitiveKeysFunc(r.CreateContext, keys)
+	r.ReadContext = withSensitiveKeysFunc(r.ReadContext, keys)
+	r.UpdateContext = withSensitiveKeysFunc(r.UpdateContext, keys)
+	r.DeleteContext = withSensitiveKeysFunc(r.DeleteContext, keys)
+	return r
+}
+
+func withSensitiveKeysFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F, keys map[string]bool) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(context.WithValue(ctx, sensitiveKeysKey{}, keys), d, meta)
+	}
+}
+
+// redactBody masks the values of the sensitive attributes known to ctx (see withSensitiveKeys) in a
+// JSON request or response body, wherever they appear in it. Bodies that aren't JSON are returned
+// as is.
+func redactBody(ctx context.Context, body []byte) []byte {
+	keys, _ := ctx.Value(sensitiveKeysKey{}).(map[string]bool)
+	if len(keys) == 0 || len(body) == 0 {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return body
+	}
+	redacted, err := json.Marshal(redactValue(v, keys))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// minRedactedSubstringLength is the minimum length of a sensitive value for it to be masked within
+// a longer string of a response, e.g. echoed in a validation error message. Shorter values are only
+// masked where they make up a whole string, as they are likely to appear in unrelated text.
+const minRedactedSubstringLength = 6
+
+// redactResponse is redactBody for response bodies, which may also echo the values of sensitive
+// attributes sent in the request elsewhere, e.g. in validation error messages. Those values are
+// masked in the string values of the body, never in its keys or structure.
+func redactResponse(ctx context.Context, body, request []byte) []byte {
+	body = redactBody(ctx, body)
+	values := sensitiveValues(ctx, request)
+	if len(values) == 0 || len(body) == 0 {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		// Not JSON, e.g. an error page of a proxy.
+		return []byte(redactString(string(body), values))
+	}
+	redacted, err := json.Marshal(redactStrings(v, values))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+// redactFieldErrors masks the values of the sensitive attributes in a JSON request body in the
+// messages of validation errors parsed from the response to it.
+func redactFieldErrors(ctx context.Context, errs []apiFieldError, request []byte) []apiFieldError {
+	values := sensitiveValues(ctx, request)
+	for i := range errs {
+		errs[i].Message = redactString(errs[i].Message, values)
+	}
+	return errs
+}
+
+func redactStrings(v interface{}, values []string) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			x[k] = redactStrings(e, values)
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = redactStrings(e, values)
+		}
+	case string:
+		return redactString(x, values)
+	}
+	return v
+}
+
+func redactString(s string, values []string) string {
+	for _, value := range values {
+		if s == value {
+			return redactedValue
+		}
+		if len(value) >= minRedactedSubstringLength {
+			s = strings.ReplaceAll(s, value, redactedValue)
+		}
+	}
+	return s
+}
+
+// sensitiveValues returns the string values of the sensitive attributes in a JSON request body,
+// including the values of sensitive maps.
+func sensitiveValues(ctx context.Context, request []byte) []string {
+	keys, _ := ctx.Value(sensitiveKeysKey{}).(map[string]bool)
+	if len(keys) == 0 || len(request) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(request, &v); err != nil {
+		return nil
+	}
+	var values []string
+	var walk func(v interface{}, sensitive bool)
+	walk = func(v interface{}, sensitive bool) {
+		switch x := v.(type) {
+		case map[string]interface{}:
+			for k, e := range x {
+				walk(e, sensitive || keys[k])
+			}
+		case []interface{}:
+			for _, e := range x {
+				walk(e, sensitive)
+			}
+		case string:
+			if sensitive && x != "" {
+				values = append(values, x)
+			}
+		}
+	}
+	walk(v, false)
+	return values
+}
+
+func redactValue(v interface{}, keys map[string]bool) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			if keys[k] && e != nil {
+				x[k] = redactedValue
+			} else {
+				x[k] = redactValue(e, keys)
+			}
+		}
+	case []interface{}:
+		for i, e := range x {
+			x[i] = redactValue(e, keys)
+		}
+	}
+	return v
+}
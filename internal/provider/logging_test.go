@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRedactBody(t *testing.T) {
+	ctx := context.WithValue(context.Background(), sensitiveKeysKey{}, sensitiveKeys(newMonitorResource().Schema))
+
+	body := []byte(`{"data":{"id":"1","attributes":{"url":"https://example.com","auth_password":"secret","auth_username":null,"environment_variables":{"PASSWORD":"passw0rd"}}}}`)
+	expected := `{"data":{"attributes":{"auth_password":"***","auth_username":null,"environment_variables":"***","url":"https://example.com"},"id":"1"}}`
+	if got := string(redactBody(ctx, body)); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	if got := string(redactBody(ctx, []byte("not json"))); got != "not json" {
+		t.Errorf("Expected non-JSON body to be kept, got %s", got)
+	}
+	if got := string(redactBody(context.Background(), body)); got != string(body) {
+		t.Errorf("Expected body to be kept without sensitive keys, got %s", got)
+	}
+}
+
+func TestRedactResponse(t *testing.T) {
+	ctx := context.WithValue(context.Background(), sensitiveKeysKey{}, sensitiveKeys(newMonitorResource().Schema))
+	request := []byte(`{"auth_password":"url","environment_variables":{"TOKEN":"t0k3n-value"}}`)
+
+	// Short values are only masked where they make up a whole string, long ones anywhere in strings.
+	body := []byte(`{"errors":{"url":["url is invalid","url"],"base":["t0k3n-value is leaked"]}}`)
+	expected := `{"errors":{"base":["*** is leaked"],"url":["url is invalid","***"]}}`
+	if got := string(redactResponse(ctx, body, request)); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	if got := string(redactResponse(ctx, []byte("<p>t0k3n-value</p>"), request)); got != "<p>***</p>" {
+		t.Errorf("Expected non-JSON body to be redacted, got %s", got)
+	}
+}
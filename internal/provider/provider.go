@@ -2,23 +2,48 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	defaultUptimeURL      = "https://uptime.betterstack.com"
+	defaultBetterStackURL = "https://betterstack.com"
 )
 
 type provider struct {
-	url     string
-	version string
+	url            string
+	betterStackURL string
+	version        string
+	checkToken     bool
 }
 
 type Option func(*provider)
 
+// WithURL sets the default base URL of both the Uptime and the Better Stack API, e.g. to point the
+// provider at a test server.
 func WithURL(v string) Option {
 	return func(p *provider) {
 		p.url = v
+		p.betterStackURL = v
+	}
+}
+
+// WithBetterStackURL sets the default base URL of the Better Stack API.
+func WithBetterStackURL(v string) Option {
+	return func(p *provider) {
+		p.betterStackURL = v
 	}
 }
 
@@ -28,21 +53,84 @@ func WithVersion(v string) Option {
 	}
 }
 
+// WithTokenCheck makes the provider check the API token when it's configured, see checkToken.
+func WithTokenCheck() Option {
+	return func(p *provider) {
+		p.checkToken = true
+	}
+}
+
 func New(opts ...Option) *schema.Provider {
 	spec := provider{
-		url: "https://uptime.betterstack.com",
+		url:            defaultUptimeURL,
+		betterStackURL: defaultBetterStackURL,
 	}
 	for _, opt := range opts {
 		opt(&spec)
 	}
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_token": {
 				Type:        schema.TypeString,
 				Sensitive:   true,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("BETTERUPTIME_API_TOKEN", nil),
-				Description: "Better Stack Uptime API token. The value can be omitted if `BETTERUPTIME_API_TOKEN` environment variable is set. See https://betterstack.com/docs/uptime/api/getting-started-with-uptime-api/#obtaining-an-uptime-api-token on how to obtain the API token for your team.",
+				Description: "Better Stack Uptime API token. The value can be omitted if `BETTERUPTIME_API_TOKEN` environment variable is set, or if `api_token_file` or `api_token_command` is used instead. See https://betterstack.com/docs/uptime/api/getting-started-with-uptime-api/#obtaining-an-uptime-api-token on how to obtain the API token for your team.",
+			},
+			"api_token_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_token", "api_token_command"},
+				Description:   "Path to a file containing the API token, e.g. one written by a secret manager agent. Surrounding whitespace is ignored.",
+			},
+			"api_token_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"api_token", "api_token_file"},
+				Description:   "Command (and its arguments) that prints the API token on stdout, e.g. `[\"vault\", \"kv\", \"get\", \"-field=token\", \"secret/betteruptime\"]`. The token is cached per provider process: Terraform starts one for each provider configuration, aliases included, and each of them runs the command once per Terraform command.",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BETTERUPTIME_API_URL", spec.url),
+				Description: "Base URL of the Uptime API, e.g. to use a staging environment or an API gateway. The value can be omitted if `BETTERUPTIME_API_URL` environment variable is set. Defaults to `" + defaultUptimeURL + "`.",
+			},
+			"better_stack_api_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("BETTERSTACK_API_URL", spec.betterStackURL),
+				Description: "Base URL of the Better Stack API, used for team members and roles. The value can be omitted if `BETTERSTACK_API_URL` environment variable is set. Defaults to `" + defaultBetterStackURL + "`.",
+			},
+			"default_team_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The team resources are created in when using a global API token and their `team_name` isn't set. Like `team_name`, it's only used when a resource is created. To manage several teams, use one aliased provider per team.",
+			},
+			"default_notifications": defaultNotificationsSchema(),
+			"default_metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "String metadata attached to the monitors, heartbeats and incoming webhooks managed by the provider, e.g. `{ managed_by = \"terraform\" }`. Keys the object already has, e.g. from a `betteruptime_metadata` resource, are left alone. Each resource lists the keys in `default_metadata`. While it is set, refreshing each of these resources takes an extra request to the metadata API.",
+			},
+			"skip_token_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip checking the API token when the provider is configured. By default, an invalid token is reported right away, and a warning is shown when `team_name` is set on a resource while using a team-scoped token, which ignores it. The warning is shown once per team name, when such a resource is refreshed (e.g. during plan), created or updated.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only read from the API, e.g. to detect drift with a read-only API token. Plans work as usual, but creating, updating or deleting any resource fails before a request is sent.",
+			},
+			"audit_log_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "File to append a JSON line to for every API request creating, updating or deleting an object. Each line has the `timestamp`, `method`, `path`, `resource_type`, `request_body` (with sensitive values redacted), `status_code` and `object_id` of the request, and the `error` if it failed. Terraform doesn't pass resource addresses to providers, so they aren't included.",
 			},
 			"api_retry_max": {
 				Type:        schema.TypeInt,
@@ -54,13 +142,13 @@ func New(opts ...Option) *schema.Provider {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     10,
-				Description: "Minimum time to wait between retries in seconds.",
+				Description: "Minimum time to wait between retries in seconds. When the API responds with a `Retry-After` header or reports the rate limit quota is exhausted, the retry waits as long as the API asks for instead, up to `api_retry_wait_max`.",
 			},
 			"api_retry_wait_max": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     300,
-				Description: "Maximum time to wait between retries in seconds.",
+				Description: "Maximum time to wait between retries in seconds, also when the API asks to wait longer.",
 			},
 			"api_timeout": {
 				Type:        schema.TypeInt,
@@ -72,7 +160,7 @@ func New(opts ...Option) *schema.Provider {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     8,
-				Description: "Maximum number of API requests per second. 0 means no limit.",
+				Description: "Maximum number of API requests per second. 0 means no limit. The provider slows down further on its own when the API reports the rate limit quota is running out. Provider configurations (e.g. aliases) using the same API token share this limit through a state file in the user cache directory, even though Terraform runs each of them in a separate process.",
 			},
 			"api_rate_burst": {
 				Type:        schema.TypeInt,
@@ -80,6 +168,35 @@ func New(opts ...Option) *schema.Provider {
 				Default:     0,
 				Description: "Burst size for rate limiter, allows temporary bursts above the rate limit. 0 means use automatic default (2x rate limit, minimum 10).",
 			},
+			"api_proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the HTTP(S) proxy to send API requests through. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are honored.",
+			},
+			"api_ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "PEM-encoded CA certificates to trust in addition to the system ones, or the path to a file containing them. Useful behind a TLS-intercepting proxy.",
+			},
+			"api_client_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"api_client_key"},
+				Description:  "PEM-encoded client certificate for mutual TLS, or the path to a file containing it. Requires `api_client_key`.",
+			},
+			"api_client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"api_client_certificate"},
+				Description:  "PEM-encoded private key of the client certificate, or the path to a file containing it. Requires `api_client_certificate`.",
+			},
+			"api_tls_min_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"1.0", "1.1", "1.2", "1.3"}, false),
+				Description:  "Minimum TLS version to accept when connecting to the API. Can be `1.0`, `1.1`, `1.2` or `1.3`. Defaults to `1.2`.",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"betteruptime_monitor":           newMonitorDataSource(),
@@ -132,20 +249,123 @@ func New(opts ...Option) *schema.Provider {
 				userAgent = "terraform-provider-better-uptime/" + spec.version
 			}
 
+			baseURL, err := validateAPIURL(r.Get("api_url").(string))
+			if err != nil {
+				return nil, diag.Diagnostics{{Severity: diag.Error, Summary: "Invalid api_url", Detail: err.Error(), AttributePath: cty.GetAttrPath("api_url")}}
+			}
+			betterStackBaseURL, err := validateAPIURL(r.Get("better_stack_api_url").(string))
+			if err != nil {
+				return nil, diag.Diagnostics{{Severity: diag.Error, Summary: "Invalid better_stack_api_url", Detail: err.Error(), AttributePath: cty.GetAttrPath("better_stack_api_url")}}
+			}
+
+			token, diags := resolveAPIToken(ctx, r)
+			if diags.HasError() {
+				return nil, diags
+			}
+
 			timeout := time.Duration(r.Get("api_timeout").(int)) * time.Second
+			transport, err := newTransport(TransportConfig{
+				ProxyURL:          r.Get("api_proxy_url").(string),
+				CABundle:          r.Get("api_ca_bundle").(string),
+				ClientCertificate: r.Get("api_client_certificate").(string),
+				ClientKey:         r.Get("api_client_key").(string),
+				TLSMinVersion:     r.Get("api_tls_min_version").(string),
+			})
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
 
 			c, err := newClient(ClientConfig{
-				BaseURL:      spec.url,
-				Token:        r.Get("api_token").(string),
-				UserAgent:    userAgent,
-				HTTPClient:   &http.Client{Timeout: timeout},
-				RetryMax:     r.Get("api_retry_max").(int),
-				RetryWaitMin: time.Duration(r.Get("api_retry_wait_min").(int)) * time.Second,
-				RetryWaitMax: time.Duration(r.Get("api_retry_wait_max").(int)) * time.Second,
-				RateLimit:    r.Get("api_rate_limit").(int),
-				RateBurst:    r.Get("api_rate_burst").(int),
+				BaseURL:            baseURL,
+				BetterStackBaseURL: betterStackBaseURL,
+				Token:              token,
+				UserAgent:          userAgent,
+				HTTPClient:         &http.Client{Timeout: timeout, Transport: transport},
+				RetryMax:           r.Get("api_retry_max").(int),
+				RetryWaitMin:       time.Duration(r.Get("api_retry_wait_min").(int)) * time.Second,
+				RetryWaitMax:       time.Duration(r.Get("api_retry_wait_max").(int)) * time.Second,
+				RateLimit:          r.Get("api_rate_limit").(int),
+				RateBurst:          r.Get("api_rate_burst").(int),
+				// Aliased providers using the same token share the API quota. Each of them runs in
+				// its own plugin process, so the rate is coordinated through a state file.
+				ShareRateLimit: true,
+				ReadOnly:       r.Get("read_only").(bool),
+				AuditLogPath:   r.Get("audit_log_path").(string),
 			})
-			return c, diag.FromErr(err)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			c.defaultTeamName = r.Get("default_team_name").(string)
+			c.defaultNotifications = loadDefaultNotifications(r)
+			c.defaultMetadata = map[string]string{}
+			for k, v := range r.Get("default_metadata").(map[string]interface{}) {
+				c.defaultMetadata[k] = v.(string)
+			}
+			if spec.checkToken && !r.Get("skip_token_check").(bool) {
+				c.tokenScope, diags = checkToken(ctx, c)
+				if diags.HasError() {
+					return nil, diags
+				}
+				if c.tokenScope == tokenScopeTeam && c.defaultTeamName != "" {
+					diags = append(diags, diag.Diagnostic{
+						Severity:      diag.Warning,
+						Summary:       "default_team_name is ignored",
+						Detail:        "The API token is bound to a single team, resources are always created in it. default_team_name only selects the team when using a global API token.",
+						AttributePath: cty.GetAttrPath("default_team_name"),
+					})
+				}
+			}
+			return c, diags
 		},
 	}
+	for _, r := range p.DataSourcesMap {
+		withSensitiveKeys(r)
+	}
+	for _, r := range p.ResourcesMap {
+		withSensitiveKeys(r)
+		withTeamNameWarning(r)
+	}
+	for name, r := range p.ResourcesMap {
+		withReadOnly(name, r)
+		withResourceType(name, r)
+		withIdentity(r)
+	}
+	return p
+}
+
+// validateAPIURL checks that v is an absolute HTTP(S) URL without query or fragment and returns it
+// without a trailing slash, so that API paths can be appended to it.
+func validateAPIURL(v string) (string, error) {
+	u, err := url.Parse(v)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("%q must be an http:// or https:// URL", v)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", v)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not have a query or fragment", v)
+	}
+	return strings.TrimSuffix(v, "/"), nil
+}
+
+// NewProviderServer returns the server of the provider, muxing the SDK provider returned by New with
+// the framework provider (see frameworkProvider) over protocol version 5.
+func NewProviderServer(ctx context.Context, opts ...Option) (func() tfprotov5.ProviderServer, error) {
+	var spec provider
+	for _, opt := range opts {
+		opt(&spec)
+	}
+	sdk := New(opts...)
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		sdk.GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider(sdk, spec.version)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return server.ProviderServer, nil
 }
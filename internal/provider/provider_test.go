@@ -1,13 +1,19 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestProvider(t *testing.T) {
@@ -64,3 +70,174 @@ func TestProviderInit(t *testing.T) {
 		t.Fatalf("HTTP server didn't receive any requests")
 	}
 }
+
+func TestProviderConfigureAPIURLs(t *testing.T) {
+	p := New()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":            "foo",
+		"api_url":              "https://uptime.staging.example.com/",
+		"better_stack_api_url": "http://gateway.internal:8080",
+	}))
+	if diags.HasError() {
+		t.Fatalf("Configure failed: %v", diags)
+	}
+	c := p.Meta().(*client)
+	if c.UptimeBaseURL() != "https://uptime.staging.example.com" {
+		t.Errorf("Unexpected Uptime API URL %q", c.UptimeBaseURL())
+	}
+	if c.BetterStackBaseURL() != "http://gateway.internal:8080" {
+		t.Errorf("Unexpected Better Stack API URL %q", c.BetterStackBaseURL())
+	}
+
+	for _, invalid := range []string{"uptime.betterstack.com", "ftp://example.com", "https://example.com?x=1"} {
+		diags := New().Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"api_token": "foo",
+			"api_url":   invalid,
+		}))
+		if !diags.HasError() {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestProviderConfigureTokenSources(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	runs := filepath.Join(dir, "runs")
+	command := []interface{}{"sh", "-c", "echo run >> " + runs + "; echo from-command"}
+
+	configure := func(config map[string]interface{}) (*schema.Provider, diag.Diagnostics) {
+		p := New()
+		return p, p.Configure(context.Background(), terraform.NewResourceConfigRaw(config))
+	}
+
+	p, diags := configure(map[string]interface{}{"api_token_file": tokenFile})
+	if diags.HasError() {
+		t.Fatalf("Configure failed: %v", diags)
+	}
+	if token := p.Meta().(*client).token; token != "from-file" {
+		t.Errorf("Expected token from file, got %q", token)
+	}
+
+	for i := 0; i < 2; i++ {
+		p, diags := configure(map[string]interface{}{"api_token_command": command})
+		if diags.HasError() {
+			t.Fatalf("Configure failed: %v", diags)
+		}
+		if token := p.Meta().(*client).token; token != "from-command" {
+			t.Errorf("Expected token from command, got %q", token)
+		}
+	}
+	if b, err := os.ReadFile(runs); err != nil || string(b) != "run\n" {
+		t.Errorf("Expected the command to run once, got %q (%v)", string(b), err)
+	}
+
+	_, diags = configure(map[string]interface{}{"api_token_command": []interface{}{"sh", "-c", "echo 'vault is sealed' >&2; exit 2"}})
+	if !diags.HasError() || !strings.Contains(diags[0].Detail, "vault is sealed") {
+		t.Errorf("Expected a diagnostic with the command's stderr, got %v", diags)
+	}
+
+	_, diags = configure(map[string]interface{}{"api_token_file": filepath.Join(dir, "missing")})
+	if !diags.HasError() {
+		t.Error("Expected an error for a missing token file")
+	}
+}
+
+func TestProviderConfigureTokenCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("Authorization") != "Bearer team" && r.Header.Get("Authorization") != "Bearer global":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"errors":"Invalid Team API token"}`))
+		case r.RequestURI == "/api/v2/monitors?per_page=1":
+			_, _ = w.Write([]byte(`{"data":[],"pagination":{"next":null}}`))
+		case r.RequestURI == "/api/v2/teams" && r.Header.Get("Authorization") == "Bearer global":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case r.RequestURI == "/api/v2/teams":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			t.Errorf("Unexpected %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	configure := func(token string) (*schema.Provider, diag.Diagnostics) {
+		p := New(WithURL(server.URL), WithTokenCheck())
+		return p, p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{"api_token": token}))
+	}
+
+	if _, diags := configure("invalid"); !diags.HasError() || diags[0].Summary != "Invalid API token" {
+		t.Errorf("Expected the invalid token to be rejected, got %v", diags)
+	}
+
+	for token, scope := range map[string]tokenScope{"global": tokenScopeGlobal, "team": tokenScopeTeam} {
+		p, diags := configure(token)
+		if diags.HasError() {
+			t.Fatalf("Configure failed: %v", diags)
+		}
+		if got := p.Meta().(*client).tokenScope; got != scope {
+			t.Errorf("Expected scope %v for %s token, got %v", scope, token, got)
+		}
+	}
+}
+
+func TestTeamNameWarning(t *testing.T) {
+	r := withTeamNameWarning(&schema.Resource{
+		Schema: map[string]*schema.Schema{"team_name": teamNameSchema()},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId("1")
+			return nil
+		},
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	})
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"team_name": "TestTeamNameWarning"})
+
+	if diags := r.CreateContext(context.Background(), d, &client{tokenScope: tokenScopeGlobal}); len(diags) != 0 {
+		t.Errorf("Expected no warning with a global token, got %v", diags)
+	}
+	if diags := r.ReadContext(context.Background(), d, &client{tokenScope: tokenScopeTeam}); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("Expected a warning when refreshing with a team token, got %v", diags)
+	}
+	// Each team_name is only warned about once per plugin process
+	if diags := r.CreateContext(context.Background(), d, &client{tokenScope: tokenScopeTeam}); len(diags) != 0 {
+		t.Errorf("Expected no second warning, got %v", diags)
+	}
+}
+
+func TestProviderConfigureDefaultNotifications(t *testing.T) {
+	p := New()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token": "foo",
+		"default_notifications": []interface{}{map[string]interface{}{
+			"call":      false,
+			"team_wait": 180,
+		}},
+	}))
+	if diags.HasError() {
+		t.Fatalf("Configure failed: %v", diags)
+	}
+	defaults := p.Meta().(*client).defaultNotifications
+	if len(defaults) != 2 || defaults["call"] != false || defaults["team_wait"] != 180 {
+		t.Errorf("Expected only the settings set explicitly, got %v", defaults)
+	}
+}
+
+func TestProviderConfigureDefaultMetadata(t *testing.T) {
+	p := New()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"api_token":        "foo",
+		"default_metadata": map[string]interface{}{"managed_by": "terraform"},
+	}))
+	if diags.HasError() {
+		t.Fatalf("Configure failed: %v", diags)
+	}
+	if defaults := p.Meta().(*client).defaultMetadata; len(defaults) != 1 || defaults["managed_by"] != "terraform" {
+		t.Errorf("Expected the configured metadata, got %v", defaults)
+	}
+}
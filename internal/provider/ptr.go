@@ -81,3 +81,8 @@ func dropUnknownKeys(in *[]map[string]interface{}, s map[string]*schema.Schema)
 	}
 	return &out
 }
+
+// sameString reports whether both pointers are set to the same value.
+func sameString(a, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
@@ -0,0 +1,429 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+)
+
+// The API reports the remaining quota either with the common X-RateLimit-* headers or with the
+// unprefixed RateLimit-* headers from the IETF draft. Both are understood.
+var (
+	rateLimitRemainingHeaders = []string{"X-RateLimit-Remaining", "RateLimit-Remaining"}
+	rateLimitResetHeaders     = []string{"X-RateLimit-Reset", "RateLimit-Reset"}
+)
+
+// parseRetryAfter parses the Retry-After header, given either in seconds or as an HTTP date.
+func parseRetryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// parseRateLimitHeaders returns the number of requests left in the current quota window and when
+// the window resets. The reset header may hold either a Unix timestamp or the number of seconds
+// until the reset; values too large to be a sensible delay are treated as timestamps.
+func parseRateLimitHeaders(h http.Header, now time.Time) (int, time.Time, bool) {
+	remaining, ok := firstIntHeader(h, rateLimitRemainingHeaders)
+	if !ok || remaining < 0 {
+		return 0, time.Time{}, false
+	}
+	reset, ok := firstIntHeader(h, rateLimitResetHeaders)
+	if !ok || reset < 0 {
+		return 0, time.Time{}, false
+	}
+	if reset > 1_000_000_000 {
+		return int(remaining), time.Unix(reset, 0), true
+	}
+	return int(remaining), now.Add(time.Duration(reset) * time.Second), true
+}
+
+func firstIntHeader(h http.Header, names []string) (int64, bool) {
+	for _, name := range names {
+		if v := h.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			return n, err == nil
+		}
+	}
+	return 0, false
+}
+
+// rateLimitBackoff waits as long as the API asks for before retrying: Retry-After on 429 and 503
+// responses, or until the quota window resets when no requests are left in it. Otherwise it falls
+// back to exponential backoff between min and max. The wait never exceeds max, so that a bogus or
+// very distant Retry-After doesn't stall the run.
+func rateLimitBackoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil {
+		now := time.Now()
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			if wait, ok := parseRetryAfter(resp.Header, now); ok {
+				return clampBackoff(resp, wait, max)
+			}
+		}
+		if remaining, reset, ok := parseRateLimitHeaders(resp.Header, now); ok && remaining == 0 {
+			if wait := reset.Sub(now); wait > 0 {
+				return clampBackoff(resp, wait, max)
+			}
+		}
+	}
+	return retryablehttp.DefaultBackoff(min, max, attemptNum, nil)
+}
+
+// clampBackoff limits the wait requested by the API to max, logging when it does.
+func clampBackoff(resp *http.Response, wait, max time.Duration) time.Duration {
+	if wait <= max {
+		return wait
+	}
+	ctx := context.Background()
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+	}
+	tflog.Warn(ctx, "API asked to wait longer than api_retry_wait_max before retrying, retrying earlier", map[string]interface{}{
+		"status":         resp.StatusCode,
+		"requested_wait": wait.String(),
+		"wait":           max.String(),
+	})
+	return max
+}
+
+// adaptiveLimiter is a rate.Limiter that slows down when the API reports the quota is running out.
+// The configured rate is an upper bound: while the API reports its quota, requests are spread
+// evenly over what's left of the current window, and after a 429 (or an exhausted quota) all
+// requests are held back until the API said it will accept them again, but never for longer than
+// maxPause.
+type adaptiveLimiter struct {
+	limiter    *rate.Limiter
+	limit      rate.Limit
+	maxPause   time.Duration
+	mu         sync.Mutex
+	pauseUntil time.Time
+	// shared is the budget for the configured rate shared with other plugin processes, if any.
+	shared *sharedBudget
+}
+
+func newAdaptiveLimiter(limit rate.Limit, burst int, maxPause time.Duration) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limiter:  rate.NewLimiter(limit, burst),
+		limit:    limit,
+		maxPause: maxPause,
+	}
+}
+
+// Wait blocks until a request may be sent, or ctx is done.
+func (l *adaptiveLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	pause := time.Until(l.pauseUntil)
+	l.mu.Unlock()
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if err := l.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	shared := l.shared
+	l.mu.Unlock()
+	if shared == nil {
+		return nil
+	}
+	if err := shared.Wait(ctx); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Coordinating with other processes is best effort, the limit still applies to this one.
+		tflog.Warn(ctx, "Failed to share the API rate limit with other provider configurations, limiting only this one", map[string]interface{}{
+			"error": err.Error(),
+		})
+		l.mu.Lock()
+		l.shared = nil
+		l.mu.Unlock()
+	}
+	return nil
+}
+
+// observe adjusts the limiter to the quota reported on a response.
+func (l *adaptiveLimiter) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if wait, ok := parseRetryAfter(resp.Header, now); ok {
+			l.pauseAt(now, now.Add(wait))
+		}
+	}
+
+	remaining, reset, ok := parseRateLimitHeaders(resp.Header, now)
+	if !ok {
+		return
+	}
+	window := reset.Sub(now)
+	if window <= 0 {
+		l.limiter.SetLimitAt(now, l.limit)
+		return
+	}
+	if remaining == 0 {
+		l.pauseAt(now, reset)
+	}
+	// Spread what's left of the quota over the rest of the window, never exceeding the configured
+	// rate. Count at least one request so the limiter never stalls completely.
+	adaptive := rate.Limit(math.Max(float64(remaining), 1) / window.Seconds())
+	if adaptive < l.limit {
+		l.limiter.SetLimitAt(now, adaptive)
+	} else {
+		l.limiter.SetLimitAt(now, l.limit)
+	}
+}
+
+func (l *adaptiveLimiter) pauseAt(now, t time.Time) {
+	if limit := now.Add(l.maxPause); t.After(limit) {
+		t = limit
+	}
+	if t.After(l.pauseUntil) {
+		l.pauseUntil = t
+	}
+}
+
+// sharedLimiters holds the rate limiters shared by all clients in the plugin process that talk to
+// the same API host with the same token. The API enforces its quota per token, so they have to share
+// one budget.
+var sharedLimiters = struct {
+	sync.Mutex
+	limiters map[string]*adaptiveLimiter
+}{limiters: map[string]*adaptiveLimiter{}}
+
+// sharedRateLimitDir holds the state files through which plugin processes share the configured rate.
+// Terraform starts a separate plugin process for every provider configuration, aliases included.
+// It's in the user's cache directory, as other users could plant files in a shared one. The rate
+// isn't shared when there's none.
+var sharedRateLimitDir = func() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "terraform-provider-better-uptime")
+}()
+
+// sharedAdaptiveLimiter returns the process-wide limiter for baseURL and token, creating it on first
+// use. When the clients sharing it are configured differently, the strictest limit, burst and
+// maximum pause win. A configured rate is also shared with other plugin processes through a state
+// file in sharedRateLimitDir.
+func sharedAdaptiveLimiter(baseURL, token string, limit rate.Limit, burst int, maxPause time.Duration) *adaptiveLimiter {
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + token))
+	key := hex.EncodeToString(sum[:])
+
+	sharedLimiters.Lock()
+	defer sharedLimiters.Unlock()
+	l, ok := sharedLimiters.limiters[key]
+	if ok {
+		l.tighten(limit, burst, maxPause)
+	} else {
+		l = newAdaptiveLimiter(limit, burst, maxPause)
+		sharedLimiters.limiters[key] = l
+	}
+	if sharedRateLimitDir != "" {
+		l.shareBudget(filepath.Join(sharedRateLimitDir, key))
+	}
+	return l
+}
+
+// tighten lowers the configured limit, burst and maximum pause if the given ones are stricter.
+func (l *adaptiveLimiter) tighten(limit rate.Limit, burst int, maxPause time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if maxPause < l.maxPause {
+		l.maxPause = maxPause
+	}
+	if limit < l.limit {
+		l.limit = limit
+		if l.limiter.Limit() > limit {
+			l.limiter.SetLimit(limit)
+		}
+	}
+	if burst < l.limiter.Burst() {
+		l.limiter.SetBurst(burst)
+	}
+}
+
+// shareBudget shares the configured rate with other processes through the state file at path. The
+// budget is replaced rather than changed, as Wait may be using it.
+func (l *adaptiveLimiter) shareBudget(path string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit == rate.Inf || l.limit <= 0 {
+		return
+	}
+	l.shared = &sharedBudget{
+		path:     path,
+		interval: time.Duration(float64(time.Second) / float64(l.limit)),
+		burst:    l.limiter.Burst(),
+		maxPause: l.maxPause,
+	}
+}
+
+// sharedBudgetLockStale is how old a lock file has to be to be considered left behind by a process
+// that died while holding it. The lock is only ever held while reading and writing the state file.
+const sharedBudgetLockStale = 10 * time.Second
+
+// sharedBudget is a rate limit shared between processes through a state file. The file holds the
+// theoretical arrival time of the next request (GCRA) in Unix nanoseconds, and is only read and
+// written while holding a lock file next to it. Like the pauses of adaptiveLimiter, waits for the
+// budget never exceed maxPause.
+type sharedBudget struct {
+	path     string
+	interval time.Duration
+	burst    int
+	maxPause time.Duration
+}
+
+// Wait blocks until a request may be sent within the shared budget, or ctx is done.
+func (b *sharedBudget) Wait(ctx context.Context) error {
+	for {
+		wait, err := b.reserve(ctx, time.Now())
+		if err != nil || wait <= 0 {
+			return err
+		}
+		if b.maxPause > 0 && wait > b.maxPause {
+			wait = b.maxPause
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a request from the budget if one is available at now, and otherwise returns how
+// long to wait before trying again.
+func (b *sharedBudget) reserve(ctx context.Context, now time.Time) (time.Duration, error) {
+	unlock, err := b.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	tolerance := time.Duration(max(b.burst-1, 0)) * b.interval
+	tat := now
+	if data, err := os.ReadFile(b.path); err == nil {
+		// A corrupted file is overwritten, so it only ever costs one burst. So is a time further
+		// ahead than a full burst, which no process sharing the budget writes, e.g. one written with
+		// a different rate or a wrong clock.
+		if n, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil && n > now.UnixNano() && n <= now.Add(tolerance+b.interval).UnixNano() {
+			tat = time.Unix(0, n)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+
+	if wait := tat.Sub(now) - tolerance; wait > 0 {
+		return wait, nil
+	}
+	// The state file is replaced by a new file, so that a file planted in its place is never
+	// written to.
+	tmp, err := os.CreateTemp(filepath.Dir(b.path), filepath.Base(b.path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.WriteString(strconv.FormatInt(tat.Add(b.interval).UnixNano(), 10))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	return 0, os.Rename(tmp.Name(), b.path)
+}
+
+// sharedBudgetDir creates the directory holding the state file of the budget, and makes sure no
+// other user can write to it: it must be a directory, not a symlink, and is made accessible only to
+// its owner, which fails unless it's the current user.
+func sharedBudgetDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s isn't a directory", dir)
+	}
+	// Windows doesn't have permission bits, the user's cache directory is private there anyway.
+	if runtime.GOOS == "windows" || info.Mode().Perm() == 0o700 {
+		return nil
+	}
+	return os.Chmod(dir, 0o700)
+}
+
+// lock creates the lock file of the budget, waiting for other processes holding it, and returns a
+// function removing it.
+func (b *sharedBudget) lock(ctx context.Context) (func(), error) {
+	if err := sharedBudgetDir(filepath.Dir(b.path)); err != nil {
+		return nil, err
+	}
+	path := b.path + ".lock"
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > sharedBudgetLockStale {
+			os.Remove(path)
+			continue
+		}
+		timer := time.NewTimer(5 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withReadOnly wraps the create, update and delete functions of a resource to fail when the
+// provider is read-only. The client refuses mutating requests on its own too, but this also covers
+// changes that wouldn't send any, e.g. deleting a resource which only exists in the state.
+func withReadOnly(name string, r *schema.Resource) *schema.Resource {
+	r.CreateContext = withReadOnlyFunc(r.CreateContext, "create", name)
+	r.UpdateContext = withReadOnlyFunc(r.UpdateContext, "update", name)
+	r.DeleteContext = withReadOnlyFunc(r.DeleteContext, "delete", name)
+	return r
+}
+
+func withReadOnlyFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F, action, name string) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if c, ok := meta.(*client); ok && c.readOnly {
+			target := name
+			if d.Id() != "" {
+				target = fmt.Sprintf("%s %s", name, d.Id())
+			}
+			return readOnlyDiagnostics(fmt.Sprintf("Refusing to %s %s, because the provider is configured with read_only = true.", action, target))
+		}
+		return f(ctx, d, meta)
+	}
+}
+
+func readOnlyDiagnostics(detail string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  "Provider is read-only",
+		Detail:   detail,
+	}}
+}
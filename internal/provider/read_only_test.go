@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestReadOnly(t *testing.T) {
+	var called bool
+	f := func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		called = true
+		return nil
+	}
+	r := withReadOnly("betteruptime_heartbeat", &schema.Resource{
+		CreateContext: f,
+		ReadContext:   f,
+		DeleteContext: f,
+		Schema:        map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}},
+	})
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	d.SetId("123")
+
+	diags := r.DeleteContext(context.Background(), d, &client{readOnly: true})
+	if !diags.HasError() || called {
+		t.Fatalf("Expected delete to be refused without calling the resource, got %v", diags)
+	}
+	if detail := diags[0].Detail; !strings.Contains(detail, "delete betteruptime_heartbeat 123") {
+		t.Errorf("Expected the detail to name the resource, got %q", detail)
+	}
+
+	if diags := r.ReadContext(context.Background(), d, &client{readOnly: true}); diags.HasError() || !called {
+		t.Errorf("Expected read to be allowed, got %v", diags)
+	}
+
+	called = false
+	if diags := r.CreateContext(context.Background(), d, &client{}); diags.HasError() || !called {
+		t.Errorf("Expected create to be allowed without read_only, got %v", diags)
+	}
+	if r.UpdateContext != nil {
+		t.Error("Expected no update function to be added")
+	}
+}
@@ -1,132 +1,194 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"io"
-	"log"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCreate(ctx context.Context, meta interface{}, url string, in, out interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&in)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s: %s", url, string(reqBody))
-	res, err := meta.(*client).Post(ctx, url, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusCreated {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
+	if _, err := apiDo(ctx, uptimeAPI(meta), http.MethodPost, url, in, out, http.StatusCreated); err != nil {
+		return apiErrorDiagnostics(err, in)
 	}
 	return nil
 }
 
-func resourceRead(ctx context.Context, meta interface{}, url string, out interface{}) (derr diag.Diagnostics, ok bool) {
-	log.Printf("GET %s", url)
-	res, err := meta.(*client).Get(ctx, url)
-	if err != nil {
-		return diag.FromErr(err), false
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	if res.StatusCode == http.StatusNotFound {
-		return nil, false
-	}
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body)), false
+// resourceCreateReconciled creates an object like resourceCreate, but never ends up creating it
+// twice. Creates aren't retried automatically when the request fails ambiguously, i.e. the
+// connection broke or the API responded with a 5xx, since the object may have been created anyway.
+// Instead, adopter looks for an object with the same natural key (e.g. a monitor's URL and type) as
+// the one being created, created since the first attempt started. If it finds one, it fills out and
+// the object is adopted; otherwise the create is retried, up to the configured number of retries.
+func resourceCreateReconciled(ctx context.Context, meta interface{}, url string, in, out interface{}, adopter createAdopter) diag.Diagnostics {
+	c := meta.(*client)
+	// created_at has a precision of a second.
+	started := time.Now().Truncate(time.Second)
+	for attempt := 0; ; attempt++ {
+		_, err := apiDo(withCreateRequest(ctx), uptimeAPI(meta), http.MethodPost, url, in, out, http.StatusCreated)
+		if err == nil {
+			return nil
+		}
+		if !isAmbiguousCreateError(ctx, err) {
+			return apiErrorDiagnostics(err, in)
+		}
+		tflog.Warn(ctx, "Create failed, looking for an object created anyway", map[string]interface{}{"http_url": url, "error": err.Error()})
+		found, lookupErr := adopter.adopt(ctx, started)
+		if lookupErr != nil {
+			return diag.Errorf("%v; looking for an object created anyway failed: %v", err, lookupErr)
+		}
+		if found {
+			tflog.Info(ctx, "Create failed, but the object was created anyway; adopting it", map[string]interface{}{"http_url": url})
+			return nil
+		}
+		if attempt >= c.retryClient.RetryMax {
+			return apiErrorDiagnostics(err, in)
+		}
+		wait := c.retryClient.Backoff(c.retryClient.RetryWaitMin, c.retryClient.RetryWaitMax, attempt, nil)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return diag.FromErr(ctx.Err())
+		case <-timer.C:
+		}
 	}
-	if err != nil {
-		return diag.FromErr(err), false
-	}
-	log.Printf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	err = json.Unmarshal(body, &out)
-	if err != nil {
-		return diag.FromErr(err), false
-	}
-	return nil, true
 }
 
-func resourceUpdate(ctx context.Context, meta interface{}, url string, req interface{}, out interface{}) diag.Diagnostics {
-	reqBody, err := json.Marshal(&req)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("PATCH %s: %s", url, string(reqBody))
-	res, err := meta.(*client).Patch(ctx, url, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
+// createAdopter finds an object created by a create request that failed ambiguously, see
+// resourceCreateReconciled.
+type createAdopter interface {
+	// adopt looks for an object with the natural key created since the given time.
+	adopt(ctx context.Context, since time.Time) (bool, error)
+}
+
+// isAmbiguousCreateError reports whether a create may have succeeded despite err.
+func isAmbiguousCreateError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
 	}
-	log.Printf("PATCH %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
 	}
-	return nil
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
-func resourceDelete(ctx context.Context, meta interface{}, url string) diag.Diagnostics {
-	log.Printf("DELETE %s", url)
-	res, err := meta.(*client).Delete(ctx, url)
+
+// adoptSingle returns a createAdopter for resourceCreateReconciled. It looks up the objects matching
+// a natural key and, if exactly one of them was created since the create started according to
+// createdAt, copies it into id and attributes. Objects without a creation time are never adopted.
+// Several new matches are an error, as it's impossible to tell which one was just created.
+func adoptSingle[T any](meta interface{}, path, key string, match func(*T) bool, createdAt func(*T) *string, id *string, attributes *T) createAdopter {
+	return &singleAdopter[T]{meta: meta, path: path, key: key, match: match, createdAt: createdAt, id: id, attributes: attributes}
+}
+
+type singleAdopter[T any] struct {
+	meta       interface{}
+	path, key  string
+	match      func(*T) bool
+	createdAt  func(*T) *string
+	id         *string
+	attributes *T
+}
+
+func (a *singleAdopter[T]) adopt(ctx context.Context, since time.Time) (bool, error) {
+	found, err := apiFind(ctx, uptimeAPI(a.meta), a.path, func(in *T) bool {
+		if !a.match(in) {
+			return false
+		}
+		createdAt := a.createdAt(in)
+		if createdAt == nil {
+			return false
+		}
+		t, err := time.Parse(time.RFC3339, *createdAt)
+		return err == nil && !t.Before(since)
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		return false, err
+	}
+	switch len(found) {
+	case 0:
+		return false, nil
+	case 1:
+		*a.id = found[0].ID
+		*a.attributes = found[0].Attributes
+		return true, nil
+	default:
+		return false, fmt.Errorf("found %d new objects with %s, can't tell which one was created", len(found), a.key)
 	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotFound {
-		return diag.Errorf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
+}
+
+// naturalKey looks up objects by a natural key, e.g. a policy's name, when importing.
+type naturalKey[T any] struct {
+	// path lists the objects that may have the value, e.g. using a filter of the index endpoint.
+	path  func(value string) string
+	match func(in *T, value string) bool
+}
+
+// importStateByNaturalKey returns an importer accepting either the ID of an object, or one of keys
+// followed by = and its value, e.g. name=Primary. The object is looked up through the index endpoint
+// and must be the only one with the value.
+func importStateByNaturalKey[T any](name string, keys map[string]naturalKey[T]) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		k, v, ok := strings.Cut(d.Id(), "=")
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+		key, ok := keys[k]
+		if !ok {
+			names := make([]string, 0, len(keys))
+			for k := range keys {
+				names = append(names, k+"=...")
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("%s must be imported by ID or %s, got %q", name, strings.Join(names, " or "), d.Id())
+		}
+		found, err := apiFind(ctx, uptimeAPI(meta), key.path(v), func(in *T) bool {
+			return key.match(in, v)
+		})
+		if err != nil {
+			return nil, err
+		}
+		switch len(found) {
+		case 0:
+			return nil, fmt.Errorf("no %s with %s %q found", name, k, v)
+		case 1:
+			d.SetId(found[0].ID)
+			return []*schema.ResourceData{d}, nil
+		default:
+			ids := make([]string, len(found))
+			for i, e := range found {
+				ids[i] = e.ID
+			}
+			return nil, fmt.Errorf("found %d %s objects with %s %q (IDs %s), import one of them by ID instead", len(found), name, k, v, strings.Join(ids, ", "))
+		}
 	}
-	log.Printf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	return nil
 }
 
-func resourceDeleteWithBaseURL(ctx context.Context, meta interface{}, baseURL, path string) diag.Diagnostics {
-	log.Printf("DELETE %s%s", baseURL, path)
-	res, err := meta.(*client).DeleteWithBaseURL(ctx, baseURL, path)
+func resourceRead(ctx context.Context, meta interface{}, url string, out interface{}) (derr diag.Diagnostics, ok bool) {
+	status, err := apiDo(ctx, uptimeAPI(meta), http.MethodGet, url, nil, out, http.StatusOK, http.StatusNotFound)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(err), false
 	}
-	defer func() {
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, _ := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusNotFound {
-		return diag.Errorf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
+	return nil, status != http.StatusNotFound
+}
+
+func resourceUpdate(ctx context.Context, meta interface{}, url string, req interface{}, out interface{}) diag.Diagnostics {
+	if _, err := apiDo(ctx, uptimeAPI(meta), http.MethodPatch, url, req, out, http.StatusOK); err != nil {
+		return apiErrorDiagnostics(err, req)
 	}
-	log.Printf("DELETE %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
 	return nil
 }
+
+func resourceDelete(ctx context.Context, meta interface{}, url string) diag.Diagnostics {
+	return diag.FromErr(apiDelete(ctx, uptimeAPI(meta), url))
+}
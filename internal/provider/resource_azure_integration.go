@@ -12,7 +12,8 @@ import (
 )
 
 var azureIntegrationSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
 	"id": {
 		Description: "The ID of the Azure Integration.",
 		Type:        schema.TypeString,
@@ -84,6 +85,7 @@ var azureIntegrationSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    false,
 		Computed:    true,
+		Sensitive:   true,
 	},
 }
 
@@ -96,7 +98,8 @@ func newAzureIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders),
+		Identity:      idResourceIdentity(),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders, applyDefaultNotifications),
 		Description:   "https://betterstack.com/docs/uptime/api/azure-integrations/",
 		Schema:        azureIntegrationSchema,
 	}
@@ -160,7 +163,7 @@ func azureIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta in
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out azureIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/azure-integrations", &in, &out); err != nil {
 		return err
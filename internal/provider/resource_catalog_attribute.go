@@ -62,6 +62,7 @@ func newCatalogAttributeResource() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		Identity:    nestedResourceIdentity("relation_id", "The ID of the Catalog relation this attribute belongs to."),
 		Description: "https://betterstack.com/docs/uptime/api/catalog-integrations-attributes/",
 		Schema:      catalogAttributeSchema,
 	}
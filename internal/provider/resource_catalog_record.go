@@ -70,6 +70,7 @@ func newCatalogRecordResource() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		Identity:      nestedResourceIdentity("relation_id", "The ID of the Catalog relation this record belongs to."),
 		CustomizeDiff: validateCatalogRecordAttributes,
 		Description:   "https://betterstack.com/docs/uptime/api/catalog-integrations-records/",
 		Schema:        catalogRecordSchema,
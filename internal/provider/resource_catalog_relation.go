@@ -45,6 +45,7 @@ func newCatalogRelationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:    idResourceIdentity(),
 		Description: "https://betterstack.com/docs/uptime/api/catalog-integrations-relations/",
 		Schema:      catalogRelationSchema,
 	}
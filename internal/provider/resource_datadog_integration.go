@@ -12,7 +12,8 @@ import (
 )
 
 var datadogIntegrationSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
 	"id": {
 		Description: "The ID of the Datadog Integration.",
 		Type:        schema.TypeString,
@@ -90,6 +91,7 @@ var datadogIntegrationSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    false,
 		Computed:    true,
+		Sensitive:   true,
 	},
 }
 
@@ -102,7 +104,8 @@ func newDatadogIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders),
+		Identity:      idResourceIdentity(),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders, applyDefaultNotifications),
 		Description:   "https://betterstack.com/docs/uptime/api/datadog-integrations/",
 		Schema:        datadogIntegrationSchema,
 	}
@@ -168,7 +171,7 @@ func datadogIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out datadogIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/datadog-integrations", &in, &out); err != nil {
 		return err
@@ -12,7 +12,8 @@ import (
 )
 
 var elasticIntegrationSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
 	"id": {
 		Description: "The ID of the Elastic Integration.",
 		Type:        schema.TypeString,
@@ -84,6 +85,7 @@ var elasticIntegrationSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    false,
 		Computed:    true,
+		Sensitive:   true,
 	},
 }
 
@@ -96,7 +98,8 @@ func newElasticIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders),
+		Identity:      idResourceIdentity(),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders, applyDefaultNotifications),
 		Description:   "https://betterstack.com/docs/uptime/api/elastic-integrations/",
 		Schema:        elasticIntegrationSchema,
 	}
@@ -160,7 +163,7 @@ func elasticIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out elasticIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/elastic-integrations", &in, &out); err != nil {
 		return err
@@ -12,7 +12,8 @@ import (
 )
 
 var emailIntegrationSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
 	"id": {
 		Description: "The ID of this Email integration.",
 		Type:        schema.TypeString,
@@ -205,8 +206,9 @@ func newEmailIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/email-integrations/",
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateIntegrationRuleConditions),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateIntegrationRuleConditions, applyDefaultNotifications),
 		Schema:        emailIntegrationSchema,
 	}
 }
@@ -303,7 +305,7 @@ func emailIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta in
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out emailIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/email-integrations", &in, &out); err != nil {
 		return err
@@ -7,11 +7,14 @@ import (
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 var heartbeatSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
+	"default_metadata":              defaultMetadataSchema(),
 	"id": {
 		Description: "The ID of this heartbeat.",
 		Type:        schema.TypeString,
@@ -170,10 +173,16 @@ func newHeartbeatResource() *schema.Resource {
 		UpdateContext: heartbeatUpdate,
 		DeleteContext: heartbeatDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByNaturalKey("betteruptime_heartbeat", map[string]naturalKey[heartbeat]{
+				"name": {
+					path:  func(string) string { return "/api/v2/heartbeats" },
+					match: func(h *heartbeat, v string) bool { return sameString(h.Name, &v) },
+				},
+			}),
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/heartbeats/",
-		CustomizeDiff: validateTeamNameNotChanged,
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, applyDefaultNotifications, applyDefaultMetadata),
 		Schema:        heartbeatSchema,
 	}
 }
@@ -252,13 +261,19 @@ func heartbeatCreate(ctx context.Context, d *schema.ResourceData, meta interface
 	for _, e := range heartbeatRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out heartbeatHTTPResponse
-	if err := resourceCreate(ctx, meta, "/api/v2/heartbeats", &in, &out); err != nil {
+	adopt := adoptSingle(meta, "/api/v2/heartbeats", "the same name", func(h *heartbeat) bool {
+		return sameString(h.Name, in.Name) && sameTeam(h.TeamName, in.TeamName)
+	}, func(h *heartbeat) *string { return h.CreatedAt }, &out.Data.ID, &out.Data.Attributes)
+	if err := resourceCreateReconciled(ctx, meta, "/api/v2/heartbeats", &in, &out, adopt); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
-	return heartbeatCopyAttrs(d, &out.Data.Attributes)
+	if derr := heartbeatCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return writeDefaultMetadata(ctx, d, meta, "Heartbeat")
 }
 
 func heartbeatRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -269,7 +284,10 @@ func heartbeatRead(ctx context.Context, d *schema.ResourceData, meta interface{}
 		d.SetId("") // Force "create" on 404.
 		return nil
 	}
-	return heartbeatCopyAttrs(d, &out.Data.Attributes)
+	if derr := heartbeatCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return readDefaultMetadata(ctx, d, meta, "Heartbeat")
 }
 
 func heartbeatCopyAttrs(d *schema.ResourceData, in *heartbeat) diag.Diagnostics {
@@ -290,7 +308,12 @@ func heartbeatUpdate(ctx context.Context, d *schema.ResourceData, meta interface
 			load(d, e.k, e.v)
 		}
 	}
-	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/heartbeats/%s", url.PathEscape(d.Id())), &in, &out)
+	if d.HasChangeExcept("default_metadata") {
+		if derr := resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/heartbeats/%s", url.PathEscape(d.Id())), &in, &out); derr != nil {
+			return derr
+		}
+	}
+	return writeDefaultMetadata(ctx, d, meta, "Heartbeat")
 }
 
 func heartbeatDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
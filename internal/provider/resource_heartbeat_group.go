@@ -61,6 +61,7 @@ func newHeartbeatGroupResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/heartbeat-groups/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        heartbeatGroupSchema,
@@ -105,7 +106,7 @@ func heartbeatGroupCreate(ctx context.Context, d *schema.ResourceData, meta inte
 	for _, e := range heartbeatGroupRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out heartbeatGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/heartbeat-groups", &in, &out); err != nil {
 		return err
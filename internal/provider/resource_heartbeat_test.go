@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -288,3 +289,95 @@ func TestResourceHeartbeatServerTimezone(t *testing.T) {
 		},
 	})
 }
+
+func TestResourceHeartbeatDefaultNotifications(t *testing.T) {
+	server := newResourceServer(t, "/api/v2/heartbeats", "1")
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"betteruptime": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "betteruptime" {
+					api_token = "foo"
+
+					default_notifications {
+						call            = false
+						sms             = true
+						team_wait       = 180
+						recovery_period = 60
+					}
+				}
+
+				resource "betteruptime_heartbeat" "this" {
+					name   = "example"
+					period = 30
+					grace  = 0
+					sms    = false
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "call", "false"),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "sms", "false"),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "team_wait", "180"),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "default_notifications_applied.#", "2"),
+					resource.TestCheckTypeSetElemAttr("betteruptime_heartbeat.this", "default_notifications_applied.*", "call"),
+					resource.TestCheckTypeSetElemAttr("betteruptime_heartbeat.this", "default_notifications_applied.*", "team_wait"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceHeartbeatDefaultMetadata(t *testing.T) {
+	server := newResourceServer(t, "/api/v2/heartbeats", "1")
+	defer server.Close()
+	server.ExpectRequest(http.MethodPost, "/api/v3/metadata", "", http.StatusCreated, `{"data":{"id":"10","attributes":{}}}`)
+	// env is overridden by an explicit betteruptime_metadata, which takes precedence over the default.
+	server.ExpectRequest(http.MethodGet, "/api/v3/metadata?owner_id=1&owner_type=Heartbeat", "", http.StatusOK, `{"data":[
+		{"id":"10","attributes":{"owner_type":"Heartbeat","owner_id":"1","key":"managed_by","values":[{"type":"String","value":"terraform"}]}},
+		{"id":"11","attributes":{"owner_type":"Heartbeat","owner_id":"1","key":"env","values":[{"type":"String","value":"staging"}]}}
+	],"pagination":{}}`)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"betteruptime": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "betteruptime" {
+					api_token = "foo"
+
+					default_metadata = {
+						managed_by = "terraform"
+						env        = "prod"
+					}
+				}
+
+				resource "betteruptime_heartbeat" "this" {
+					name   = "example"
+					period = 30
+					grace  = 0
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					server.TestCheckCalledRequest(http.MethodPost, "/api/v3/metadata", `{"owner_type":"Heartbeat","owner_id":"1","key":"env","values":[{"type":"String","value":"prod"}]}`),
+					server.TestCheckCalledRequest(http.MethodPost, "/api/v3/metadata", `{"owner_type":"Heartbeat","owner_id":"1","key":"managed_by","values":[{"type":"String","value":"terraform"}]}`),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "default_metadata.%", "2"),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "default_metadata.managed_by", "terraform"),
+					resource.TestCheckResourceAttr("betteruptime_heartbeat.this", "default_metadata.env", "staging"),
+				),
+			},
+		},
+	})
+}
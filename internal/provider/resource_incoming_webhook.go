@@ -12,7 +12,9 @@ import (
 )
 
 var incomingWebhookSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
+	"default_metadata":              defaultMetadataSchema(),
 	"id": {
 		Description: "The ID of this incoming webhook.",
 		Type:        schema.TypeString,
@@ -84,6 +86,7 @@ var incomingWebhookSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    false,
 		Computed:    true,
+		Sensitive:   true,
 	},
 	"sample_query_string": {
 		Description: "Sample query string of the webhook (without the leading ?). Used only to make the configuration easier.",
@@ -223,8 +226,9 @@ func newIncomingWebhookResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/list-all-incoming-webhooks/",
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateIntegrationRuleConditions),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateIntegrationRuleConditions, applyDefaultNotifications, applyDefaultMetadata),
 		Schema:        incomingWebhookSchema,
 	}
 }
@@ -327,13 +331,16 @@ func incomingWebhookCreate(ctx context.Context, d *schema.ResourceData, meta int
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out incomingWebhookHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/incoming-webhooks", &in, &out); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
-	return incomingWebhookCopyAttrs(d, &out.Data.Attributes)
+	if derr := incomingWebhookCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return writeDefaultMetadata(ctx, d, meta, "IncomingWebhook")
 }
 
 func incomingWebhookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -344,7 +351,10 @@ func incomingWebhookRead(ctx context.Context, d *schema.ResourceData, meta inter
 		d.SetId("") // Force "create" on 404.
 		return nil
 	}
-	return incomingWebhookCopyAttrs(d, &out.Data.Attributes)
+	if derr := incomingWebhookCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return readDefaultMetadata(ctx, d, meta, "IncomingWebhook")
 }
 
 func incomingWebhookCopyAttrs(d *schema.ResourceData, in *incomingWebhook) diag.Diagnostics {
@@ -383,7 +393,12 @@ func incomingWebhookUpdate(ctx context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/incoming-webhooks/%s", url.PathEscape(d.Id())), &in, &out)
+	if d.HasChangeExcept("default_metadata") {
+		if derr := resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/incoming-webhooks/%s", url.PathEscape(d.Id())), &in, &out); derr != nil {
+			return derr
+		}
+	}
+	return writeDefaultMetadata(ctx, d, meta, "IncomingWebhook")
 }
 
 func incomingWebhookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
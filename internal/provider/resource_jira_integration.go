@@ -65,6 +65,7 @@ func newJiraIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:    idResourceIdentity(),
 		Description: "https://betterstack.com/docs/uptime/api/jira-integrations/",
 		Schema:      jiraIntegrationSchema,
 	}
@@ -1,12 +1,9 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"reflect"
@@ -61,7 +58,6 @@ var metadataSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
 		Default:     nil,
-		Deprecated:  "Use repeatable block metadata_value to define values with types instead.",
 	},
 	"metadata_value": {
 		Description: "An array of typed metadata values of this Metadata.",
@@ -166,10 +162,75 @@ func newMetadataResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateMetadata),
 		Description:   "https://betterstack.com/docs/uptime/api/metadata/",
 		Schema:        metadataSchema,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    metadataResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: metadataStateUpgradeV0,
+			},
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateMetadataDeprecations},
+	}
+}
+
+// metadataValueResourceV0 is the schema of metadata values in states of version 0.
+func metadataValueResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type":    {Type: schema.TypeString, Optional: true},
+			"value":   {Type: schema.TypeString, Optional: true},
+			"item_id": {Type: schema.TypeString, Optional: true},
+			"name":    {Type: schema.TypeString, Optional: true},
+			"email":   {Type: schema.TypeString, Optional: true},
+		},
+	}
+}
+
+// metadataResourceV0 is the schema of betteruptime_metadata states of version 0. It must not change
+// along with metadataSchema, as it describes states already written.
+func metadataResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_name":      {Type: schema.TypeString, Optional: true},
+			"id":             {Type: schema.TypeString, Computed: true},
+			"owner_type":     {Type: schema.TypeString, Required: true},
+			"owner_id":       {Type: schema.TypeString, Required: true},
+			"key":            {Type: schema.TypeString, Required: true},
+			"value":          {Type: schema.TypeString, Optional: true},
+			"metadata_value": {Type: schema.TypeList, Optional: true, Elem: metadataValueResourceV0()},
+			"created_at":     {Type: schema.TypeString, Computed: true},
+			"updated_at":     {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+// metadataStateUpgradeV0 copies the deprecated value into a metadata_value block, which is what the
+// API returns it as. value is kept in the state until it's removed from the configuration, so that
+// the upgrade doesn't cause a diff.
+func metadataStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	value, _ := rawState["value"].(string)
+	if value != "" && len(upgradeList(rawState, "metadata_value")) == 0 {
+		rawState["metadata_value"] = []interface{}{
+			map[string]interface{}{"type": "String", "value": value, "item_id": "", "name": "", "email": ""},
+		}
+	}
+	return rawState, nil
+}
+
+func validateMetadataDeprecations(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !configSet(req.RawConfig, "value") {
+		return
+	}
+	var replacement []string
+	if v, ok := configAttr(req.RawConfig, "value"); ok {
+		replacement = append(replacement, formatHCL("metadata_value", "value", v.AsString()))
 	}
+	resp.Diagnostics = append(resp.Diagnostics, deprecatedAttributeWarning(cty.GetAttrPath("value"), "value", "repeatable block metadata_value", replacement...))
 }
 
 type metadata struct {
@@ -233,36 +294,9 @@ func metadataCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func metadataPost(ctx context.Context, meta interface{}, in *metadata, out *metadataHTTPResponse) diag.Diagnostics {
-	reqBody, err := json.Marshal(&in)
-	apiUrl := "/api/v3/metadata"
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s: %s", apiUrl, string(reqBody))
-	res, err := meta.(*client).Post(ctx, apiUrl, bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		// Keep-Alive.
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
 	// There are 3 success status codes (200, 201, and 204) in this API endpoint, we don't need to distinguish between them
-	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	if res.StatusCode == http.StatusNoContent {
-		// Return early on no content
-		return nil
-	}
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
+	if _, err := apiDo(ctx, uptimeAPI(meta), http.MethodPost, "/api/v3/metadata", in, out, http.StatusCreated, http.StatusOK, http.StatusNoContent); err != nil {
+		return apiErrorDiagnostics(err, in)
 	}
 	return nil
 }
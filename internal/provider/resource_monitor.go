@@ -18,7 +18,9 @@ import (
 var monitorTypes = []string{"status", "expected_status_code", "keyword", "keyword_absence", "ping", "tcp", "udp", "smtp", "pop", "imap", "dns", "playwright"}
 var ipVersions = []string{"ipv4", "ipv6"}
 var monitorSchema = map[string]*schema.Schema{
-	"team_name": teamNameSchema(),
+	"team_name":                     teamNameSchema(),
+	"default_notifications_applied": defaultNotificationsAppliedSchema(),
+	"default_metadata":              defaultMetadataSchema(),
 	"id": {
 		Description: "The ID of this Monitor.",
 		Type:        schema.TypeString,
@@ -287,6 +289,8 @@ var monitorSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Sensitive:   true,
 	},
+	"auth_password_wo":         writeOnlySchema("auth_password"),
+	"auth_password_wo_version": writeOnlyVersionSchema("auth_password"),
 	"proxy_host": {
 		Description: "A proxy to be used for routing HTTP checks. Use user:pass@hostname format for proxy authentication.",
 		Type:        schema.TypeString,
@@ -429,6 +433,17 @@ var monitorSchema = map[string]*schema.Schema{
 			return nil
 		},
 	},
+	"environment_variables_wo": {
+		Description:   "Write-only alternative to `environment_variables` as a JSON object, e.g. `jsonencode({ PASSWORD = \"passw0rd\" })`, which isn't stored in the state. Requires Terraform 1.11 or later. Change `environment_variables_wo_version` to update it.",
+		Type:          schema.TypeString,
+		Optional:      true,
+		WriteOnly:     true,
+		Sensitive:     true,
+		ConflictsWith: []string{"environment_variables"},
+		RequiredWith:  []string{"environment_variables_wo_version"},
+		ValidateFunc:  validation.StringIsJSON,
+	},
+	"environment_variables_wo_version": writeOnlyVersionSchema("environment_variables"),
 }
 
 func newMonitorResource() *schema.Resource {
@@ -438,9 +453,15 @@ func newMonitorResource() *schema.Resource {
 		UpdateContext: monitorUpdate,
 		DeleteContext: monitorDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByNaturalKey("betteruptime_monitor", map[string]naturalKey[monitor]{
+				"url": {
+					path:  func(v string) string { return monitorNaturalKeyPath(&monitor{URL: &v}) },
+					match: func(m *monitor, v string) bool { return sameString(m.URL, &v) },
+				},
+			}),
 		},
-		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateMonitor),
+		Identity:      idResourceIdentity(),
+		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateMonitor, applyDefaultNotifications, applyDefaultMetadata),
 		Description:   "https://betterstack.com/docs/uptime/api/monitors/",
 		Schema:        monitorSchema,
 	}
@@ -588,13 +609,26 @@ func monitorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	if err := loadWriteOnly(d, "auth_password", &in.AuthPassword); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := loadWriteOnly(d, "environment_variables", &in.EnvironmentVariables); err != nil {
+		return diag.FromErr(err)
+	}
+	loadTeamName(d, meta, &in.TeamName)
 	var out monitorHTTPResponse
-	if err := resourceCreate(ctx, meta, "/api/v2/monitors", &in, &out); err != nil {
+	adopt := adoptSingle(meta, monitorNaturalKeyPath(&in), "the same url and monitor_type", func(m *monitor) bool {
+		// Playwright monitors may have no URL, and thus no natural key.
+		return in.URL != nil && *in.URL != "" && sameString(m.URL, in.URL) && sameString(m.MonitorType, in.MonitorType) && sameTeam(m.TeamName, in.TeamName)
+	}, func(m *monitor) *string { return m.CreatedAt }, &out.Data.ID, &out.Data.Attributes)
+	if err := resourceCreateReconciled(ctx, meta, "/api/v2/monitors", &in, &out, adopt); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
-	return monitorCopyAttrs(d, &out.Data.Attributes)
+	if derr := monitorCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return writeDefaultMetadata(ctx, d, meta, "Monitor")
 }
 
 func monitorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -605,7 +639,18 @@ func monitorRead(ctx context.Context, d *schema.ResourceData, meta interface{})
 		d.SetId("") // Force "create" on 404.
 		return nil
 	}
-	return monitorCopyAttrs(d, &out.Data.Attributes)
+	if derr := monitorCopyAttrs(d, &out.Data.Attributes); derr != nil {
+		return derr
+	}
+	return readDefaultMetadata(ctx, d, meta, "Monitor")
+}
+
+// monitorNaturalKeyPath lists the monitors that may match in by URL, using the API's url filter.
+func monitorNaturalKeyPath(in *monitor) string {
+	if in.URL == nil {
+		return "/api/v2/monitors"
+	}
+	return fmt.Sprintf("/api/v2/monitors?url=%s", url.QueryEscape(*in.URL))
 }
 
 func monitorCopyAttrs(d *schema.ResourceData, in *monitor) diag.Diagnostics {
@@ -635,6 +680,11 @@ func monitorCopyAttrs(d *schema.ResourceData, in *monitor) diag.Diagnostics {
 					derr = append(derr, diag.FromErr(err)[0])
 				}
 			}
+		} else if (e.k == "auth_password" || e.k == "environment_variables") && usesWriteOnly(d, e.k) {
+			// Set through the write-only attribute, keep the value out of the state.
+			if err := d.Set(e.k, nil); err != nil {
+				derr = append(derr, diag.FromErr(err)[0])
+			}
 		} else if err := d.Set(e.k, reflect.Indirect(reflect.ValueOf(e.v)).Interface()); err != nil {
 			derr = append(derr, diag.FromErr(err)[0])
 		}
@@ -674,8 +724,19 @@ func monitorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}
 			}
 		}
 	}
+	if err := loadWriteOnly(d, "auth_password", &in.AuthPassword); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := loadWriteOnly(d, "environment_variables", &in.EnvironmentVariables); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/monitors/%s", url.PathEscape(d.Id())), &in, &out)
+	if d.HasChangeExcept("default_metadata") {
+		if derr := resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/monitors/%s", url.PathEscape(d.Id())), &in, &out); derr != nil {
+			return derr
+		}
+	}
+	return writeDefaultMetadata(ctx, d, meta, "Monitor")
 }
 
 func monitorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
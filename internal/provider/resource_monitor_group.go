@@ -61,6 +61,7 @@ func newMonitorGroupResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/monitor-groups/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        monitorGroupSchema,
@@ -105,7 +106,7 @@ func monitorGroupCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	for _, e := range monitorGroupRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out monitorGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/monitor-groups", &in, &out); err != nil {
 		return err
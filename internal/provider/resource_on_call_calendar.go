@@ -131,6 +131,7 @@ func newOnCallCalendarResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        onCallCalendarSchema,
 		Description:   "https://betterstack.com/docs/uptime/api/on-call-calendar/",
@@ -260,7 +261,7 @@ func resourceOnCallCalendarCreate(ctx context.Context, d *schema.ResourceData, m
 	for _, e := range onCallCalendarRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 
 	var out struct {
 		Data struct {
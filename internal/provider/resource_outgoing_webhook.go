@@ -194,6 +194,7 @@ func newOutgoingWebhookResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/outgoing-webhook-integrations/",
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateOutgoingWebhook),
 		Schema:        outgoingWebhookSchema,
@@ -241,7 +242,7 @@ func outgoingWebhookCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	// Load team name
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 
 	// Handle custom webhook template attributes
 	if v, ok := d.GetOk("custom_webhook_template_attributes"); ok && len(v.([]interface{})) > 0 {
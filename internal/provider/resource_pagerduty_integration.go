@@ -27,10 +27,14 @@ var pagerdutyIntegrationSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"key": {
-		Description: "The PagerDuty routing key.",
-		Type:        schema.TypeString,
-		Required:    true,
+		Description:  "The PagerDuty routing key. Exactly one of `key` and `key_wo` must be set.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ExactlyOneOf: []string{"key", "key_wo"},
 	},
+	"key_wo":         writeOnlySchema("key"),
+	"key_wo_version": writeOnlyVersionSchema("key"),
 	"severity": {
 		Description:  "The PagerDuty alert severity. Can be any of the following: info, warning, error, or critical.",
 		Type:         schema.TypeString,
@@ -54,6 +58,7 @@ func newPagerdutyIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders),
 		Description:   "https://betterstack.com/docs/uptime/api/pagerduty-integrations/",
 		Schema:        pagerdutyIntegrationSchema,
@@ -104,7 +109,10 @@ func pagerdutyIntegrationCreate(ctx context.Context, d *schema.ResourceData, met
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	if err := loadWriteOnly(d, "key", &in.Key); err != nil {
+		return diag.FromErr(err)
+	}
+	loadTeamName(d, meta, &in.TeamName)
 	var out pagerdutyIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/pager-duty-webhooks", &in, &out); err != nil {
 		return err
@@ -127,6 +135,10 @@ func pagerdutyIntegrationRead(ctx context.Context, d *schema.ResourceData, meta
 func pagerdutyIntegrationCopyAttrs(d *schema.ResourceData, in *pagerdutyIntegration) diag.Diagnostics {
 	var derr diag.Diagnostics
 	for _, e := range pagerdutyIntegrationRef(in) {
+		if e.k == "key" && usesWriteOnly(d, e.k) {
+			// Set through key_wo, keep the routing key out of the state.
+			continue
+		}
 		if err := d.Set(e.k, reflect.Indirect(reflect.ValueOf(e.v)).Interface()); err != nil {
 			derr = append(derr, diag.FromErr(err)[0])
 		}
@@ -148,6 +160,9 @@ func pagerdutyIntegrationUpdate(ctx context.Context, d *schema.ResourceData, met
 			}
 		}
 	}
+	if err := loadWriteOnly(d, "key", &in.Key); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/pager-duty-webhooks/%s", url.PathEscape(d.Id())), &in, &out)
 }
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -162,3 +165,88 @@ func TestResourcePagerDutyIntegrationSimpleEscalationOptOut(t *testing.T) {
 		},
 	})
 }
+
+func TestResourcePagerDutyIntegrationWriteOnlyKey(t *testing.T) {
+	server := newResourceServer(t, "/api/v2/pager-duty-webhooks", "1")
+	defer server.Close()
+
+	config := func(key string, version int) string {
+		return fmt.Sprintf(`
+		provider "betteruptime" {
+			api_token = "foo"
+		}
+
+		resource "betteruptime_pagerduty_integration" "this" {
+			name           = "test"
+			key_wo         = "%s"
+			key_wo_version = %d
+			severity       = "critical"
+		}
+		`, key, version)
+	}
+	sentKey := func(method, key string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			var last CalledRequest
+			for _, req := range server.CalledRequests {
+				if req.Method == method {
+					last = req
+				}
+			}
+			if !strings.Contains(last.Body, fmt.Sprintf(`"key":%q`, key)) {
+				return fmt.Errorf("expected last %s body to contain key %q, got %s", method, key, last.Body)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"betteruptime": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			// Step 1 - create, the key is sent but not stored in the state.
+			{
+				Config: config("key1", 1),
+				Check: resource.ComposeTestCheckFunc(
+					sentKey("POST", "key1"),
+					resource.TestCheckResourceAttr("betteruptime_pagerduty_integration.this", "key", ""),
+					resource.TestCheckNoResourceAttr("betteruptime_pagerduty_integration.this", "key_wo"),
+					resource.TestCheckResourceAttr("betteruptime_pagerduty_integration.this", "key_wo_version", "1"),
+				),
+			},
+			// Step 2 - a new key without a new version isn't sent.
+			{
+				Config:   config("key2", 1),
+				PlanOnly: true,
+			},
+			// Step 3 - bump the version to send the new key.
+			{
+				Config: config("key2", 2),
+				Check: resource.ComposeTestCheckFunc(
+					sentKey("PATCH", "key2"),
+					resource.TestCheckResourceAttr("betteruptime_pagerduty_integration.this", "key", ""),
+				),
+			},
+			// Step 4 - both forms can't be set at once.
+			{
+				Config: `
+				provider "betteruptime" {
+					api_token = "foo"
+				}
+
+				resource "betteruptime_pagerduty_integration" "this" {
+					name           = "test"
+					key            = "key3"
+					key_wo         = "key3"
+					key_wo_version = 3
+					severity       = "critical"
+				}
+				`,
+				ExpectError: regexp.MustCompile(`"key_wo": conflicts with key|only one of`),
+			},
+		},
+	})
+}
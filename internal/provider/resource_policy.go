@@ -6,7 +6,10 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -25,6 +28,12 @@ var policyStepMemberSchema = map[string]*schema.Schema{
 		Type:        schema.TypeInt,
 		Optional:    true,
 		Default:     nil,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			// States upgraded from version 0 carry the deprecated team_id in id as well, see
+			// policyStateUpgradeV0, which mustn't show as a diff while team_id is still used.
+			teamID, ok := d.GetOk(strings.TrimSuffix(k, "id") + "team_id")
+			return (new == "" || new == "0") && ok && strconv.Itoa(teamID.(int)) == old
+		},
 	},
 	"email": {
 		Description: "The e-mail address of the user to notify during an incident. Can be used instead of id when member type is user - it is resolved to the user's ID automatically. Only one of id and email can be set.",
@@ -43,7 +52,6 @@ var policyStepMemberSchema = map[string]*schema.Schema{
 		Type:        schema.TypeInt,
 		Optional:    true,
 		Default:     nil,
-		Deprecated:  "Use id instead.",
 	},
 }
 
@@ -137,12 +145,11 @@ var policyStepSchema = map[string]*schema.Schema{
 		Default:     nil,
 	},
 	"metadata_values": {
-		Description: "An array of metadata String values which will cause the branching rule to be executed. Used when step type is metadata_branching.",
+		Description: "An array of metadata String values which will cause the branching rule to be executed. Used when step type is metadata_branching. This field is deprecated, use repeatable block metadata_value to define values with types instead.",
 		Type:        schema.TypeList,
 		Elem:        &schema.Schema{Type: schema.TypeString},
 		Optional:    true,
 		Default:     nil,
-		Deprecated:  "Use repeatable block metadata_value to define values with types instead.",
 	},
 	"metadata_value": {
 		Description: "An array of typed metadata values which will cause the branching rule to be executed. Used when step type is metadata_branching.",
@@ -242,11 +249,129 @@ func newPolicyResource() *schema.Resource {
 		UpdateContext: resourcePolicyUpdate,
 		DeleteContext: resourcePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByNaturalKey("betteruptime_policy", map[string]naturalKey[policy]{
+				"name": {
+					path:  func(string) string { return "/api/v3/policies" },
+					match: func(p *policy, v string) bool { return sameString(p.Name, &v) },
+				},
+			}),
 		},
+		Identity:      idResourceIdentity(),
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validatePolicy),
 		Schema:        policySchema,
 		Description:   "https://betterstack.com/docs/uptime/api/policies/",
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    policyResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: policyStateUpgradeV0,
+			},
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validatePolicyDeprecations},
+	}
+}
+
+// policyResourceV0 is the schema of betteruptime_policy states of version 0. It must not change
+// along with policySchema, as it describes states already written.
+func policyResourceV0() *schema.Resource {
+	member := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type":         {Type: schema.TypeString, Required: true},
+			"id":           {Type: schema.TypeInt, Optional: true},
+			"email":        {Type: schema.TypeString, Optional: true},
+			"metadata_key": {Type: schema.TypeString, Optional: true},
+			"team_id":      {Type: schema.TypeInt, Optional: true},
+		},
+	}
+	step := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type":                    {Type: schema.TypeString, Required: true},
+			"wait_before":             {Type: schema.TypeInt, Optional: true},
+			"wait_until_time":         {Type: schema.TypeString, Optional: true},
+			"wait_until_timezone":     {Type: schema.TypeString, Optional: true},
+			"urgency_id":              {Type: schema.TypeInt, Optional: true},
+			"step_members":            {Type: schema.TypeList, Optional: true, Elem: member},
+			"timezone":                {Type: schema.TypeString, Optional: true},
+			"days":                    {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"time_from":               {Type: schema.TypeString, Optional: true},
+			"time_to":                 {Type: schema.TypeString, Optional: true},
+			"metadata_key":            {Type: schema.TypeString, Optional: true},
+			"metadata_values":         {Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"metadata_value":          {Type: schema.TypeList, Optional: true, Elem: metadataValueResourceV0()},
+			"policy_id":               {Type: schema.TypeInt, Optional: true},
+			"policy_metadata_key":     {Type: schema.TypeString, Optional: true},
+			"comment":                 {Type: schema.TypeString, Optional: true},
+			"reminder_enabled":        {Type: schema.TypeBool, Optional: true, Computed: true},
+			"reminder_interval_hours": {Type: schema.TypeInt, Optional: true, Computed: true},
+		},
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_name":          {Type: schema.TypeString, Optional: true},
+			"id":                 {Type: schema.TypeString, Computed: true},
+			"name":               {Type: schema.TypeString, Required: true},
+			"repeat_count":       {Type: schema.TypeInt, Optional: true, Computed: true},
+			"repeat_delay":       {Type: schema.TypeInt, Optional: true, Computed: true},
+			"fallback_policy_id": {Type: schema.TypeInt, Optional: true, Computed: true},
+			"incident_token":     {Type: schema.TypeString, Computed: true},
+			"steps":              {Type: schema.TypeList, Optional: true, Elem: step},
+			"policy_group_id":    {Type: schema.TypeInt, Optional: true, Computed: true},
+		},
+	}
+}
+
+// policyStateUpgradeV0 copies the deprecated metadata_values of steps into metadata_value blocks,
+// which is what the API returns them as, and the deprecated team_id of step members into id. The
+// deprecated values are kept in the state until they're removed from the configuration, so that
+// the upgrade doesn't cause a diff.
+func policyStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	for _, step := range upgradeList(rawState, "steps") {
+		legacyValues, _ := step["metadata_values"].([]interface{})
+		if len(legacyValues) > 0 && len(upgradeList(step, "metadata_value")) == 0 {
+			values := make([]interface{}, 0, len(legacyValues))
+			for _, v := range legacyValues {
+				values = append(values, map[string]interface{}{"type": "String", "value": v, "item_id": "", "name": "", "email": ""})
+			}
+			step["metadata_value"] = values
+		}
+		for _, member := range upgradeList(step, "step_members") {
+			// Numbers of the raw state are decoded from JSON as float64.
+			if teamID, _ := member["team_id"].(float64); teamID != 0 {
+				if id, _ := member["id"].(float64); id == 0 {
+					member["id"] = teamID
+				}
+			}
+		}
+	}
+	return rawState, nil
+}
+
+func validatePolicyDeprecations(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	for i, step := range configList(req.RawConfig, "steps") {
+		path := cty.GetAttrPath("steps").IndexInt(i)
+		if configSet(step, "metadata_values") {
+			var blocks []string
+			for _, v := range configList(step, "metadata_values") {
+				if v.IsNull() || !v.IsKnown() {
+					blocks = nil
+					break
+				}
+				blocks = append(blocks, formatHCL("metadata_value", "value", v.AsString()))
+			}
+			resp.Diagnostics = append(resp.Diagnostics, deprecatedAttributeWarning(path.GetAttr("metadata_values"), "metadata_values", "repeatable block metadata_value", blocks...))
+		}
+		for j, member := range configList(step, "step_members") {
+			if !configSet(member, "team_id") {
+				continue
+			}
+			var replacement []string
+			if v, ok := configAttr(member, "team_id"); ok {
+				id, _ := v.AsBigFloat().Int64()
+				replacement = append(replacement, formatHCL("", "id", id))
+			}
+			resp.Diagnostics = append(resp.Diagnostics, deprecatedAttributeWarning(path.GetAttr("step_members").IndexInt(j).GetAttr("team_id"), "team_id", "id", replacement...))
+		}
 	}
 }
 
@@ -289,6 +414,7 @@ type policy struct {
 	Steps            *[]policyStep `json:"steps"`
 	TeamName         *string       `json:"team_name,omitempty"`
 	PolicyGroupID    *int          `json:"policy_group_id,omitempty"`
+	CreatedAt        *string       `json:"created_at,omitempty"`
 }
 
 type policyHTTPResponse struct {
@@ -328,9 +454,12 @@ func resourcePolicyCreate(ctx context.Context, d *schema.ResourceData, meta inte
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out policyHTTPResponse
-	if err := resourceCreate(ctx, meta, "/api/v3/policies", &in, &out); err != nil {
+	adopt := adoptSingle(meta, "/api/v3/policies", "the same name", func(p *policy) bool {
+		return sameString(p.Name, in.Name) && sameTeam(p.TeamName, in.TeamName)
+	}, func(p *policy) *string { return p.CreatedAt }, &out.Data.ID, &out.Data.Attributes)
+	if err := resourceCreateReconciled(ctx, meta, "/api/v3/policies", &in, &out, adopt); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
@@ -378,6 +507,10 @@ func policyCopyAttrs(d *schema.ResourceData, in *policy) diag.Diagnostics {
 					if value, ok := d.GetOk(fmt.Sprintf("steps.%d.step_members.%d.email", stepIndex, memberIndex)); !ok || value == "" {
 						(*(*in.Steps)[stepIndex].Members)[memberIndex].Email = nil
 					}
+					// The deprecated team_id duplicates id, keep it only while it's still used.
+					if value, ok := d.GetOk(fmt.Sprintf("steps.%d.step_members.%d.team_id", stepIndex, memberIndex)); !ok || value == 0 {
+						(*(*in.Steps)[stepIndex].Members)[memberIndex].TeamId = nil
+					}
 				}
 			}
 		}
@@ -55,6 +55,7 @@ func newPolicyGroupResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/policy-groups/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        policyGroupSchema,
@@ -97,7 +98,7 @@ func policyGroupCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	for _, e := range policyGroupRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out policyGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/policy-groups", &in, &out); err != nil {
 		return err
@@ -70,6 +70,7 @@ func newSeverityResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/list-all-severities/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        severitySchema,
@@ -119,7 +120,7 @@ func severityCreate(ctx context.Context, d *schema.ResourceData, meta interface{
 	for _, e := range severityRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out severityHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/urgencies", &in, &out); err != nil {
 		return err
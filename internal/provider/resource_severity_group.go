@@ -55,6 +55,7 @@ func newSeverityGroupResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/urgency-groups/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        severityGroupSchema,
@@ -97,7 +98,7 @@ func severityGroupCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	for _, e := range severityGroupRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out severityGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/urgency-groups", &in, &out); err != nil {
 		return err
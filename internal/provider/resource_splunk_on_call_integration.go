@@ -26,10 +26,14 @@ var splunkOnCallIntegrationSchema = map[string]*schema.Schema{
 		Computed:    true,
 	},
 	"url": {
-		Description: "The Splunk On-Call URL to post webhooks to.",
-		Type:        schema.TypeString,
-		Required:    true,
+		Description:  "The Splunk On-Call URL to post webhooks to, which includes the routing key. Exactly one of `url` and `url_wo` must be set.",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ExactlyOneOf: []string{"url", "url_wo"},
 	},
+	"url_wo":         writeOnlySchema("url"),
+	"url_wo_version": writeOnlyVersionSchema("url"),
 	"notify_alongside_primary_responder": {
 		Description: "Whether this integration should be notified alongside the primary responder when no escalation policy is configured. Defaults to `true`.",
 		Type:        schema.TypeBool,
@@ -47,6 +51,7 @@ func newSplunkOnCallIntegrationResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		CustomizeDiff: customdiff.Sequence(validateTeamNameNotChanged, validateRequestHeaders),
 		Description:   "https://betterstack.com/docs/uptime/api/splunk-on-call-integrations/",
 		Schema:        splunkOnCallIntegrationSchema,
@@ -95,7 +100,10 @@ func splunkOnCallIntegrationCreate(ctx context.Context, d *schema.ResourceData,
 			load(d, e.k, e.v)
 		}
 	}
-	load(d, "team_name", &in.TeamName)
+	if err := loadWriteOnly(d, "url", &in.URL); err != nil {
+		return diag.FromErr(err)
+	}
+	loadTeamName(d, meta, &in.TeamName)
 	var out splunkOnCallIntegrationHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/splunk-on-calls", &in, &out); err != nil {
 		return err
@@ -118,6 +126,10 @@ func splunkOnCallIntegrationRead(ctx context.Context, d *schema.ResourceData, me
 func splunkOnCallIntegrationCopyAttrs(d *schema.ResourceData, in *splunkOnCallIntegration) diag.Diagnostics {
 	var derr diag.Diagnostics
 	for _, e := range splunkOnCallIntegrationRef(in) {
+		if e.k == "url" && usesWriteOnly(d, e.k) {
+			// Set through url_wo, keep the routing key out of the state.
+			continue
+		}
 		if err := d.Set(e.k, reflect.Indirect(reflect.ValueOf(e.v)).Interface()); err != nil {
 			derr = append(derr, diag.FromErr(err)[0])
 		}
@@ -139,6 +151,9 @@ func splunkOnCallIntegrationUpdate(ctx context.Context, d *schema.ResourceData,
 			}
 		}
 	}
+	if err := loadWriteOnly(d, "url", &in.URL); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/splunk-on-calls/%s", url.PathEscape(d.Id())), &in, &out)
 }
@@ -160,6 +160,8 @@ var statusPageSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Sensitive:   true,
 	},
+	"password_wo":         writeOnlySchema("password"),
+	"password_wo_version": writeOnlyVersionSchema("password"),
 	"require_sso": {
 		Description: "Require SSO sign-in to access your status page. Requires SSO to be configured for your organization and is mutually exclusive with password protection.",
 		Type:        schema.TypeBool,
@@ -251,8 +253,14 @@ func newStatusPageResource() *schema.Resource {
 		DeleteContext: statusPageDelete,
 		CustomizeDiff: statusPageCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateByNaturalKey("betteruptime_status_page", map[string]naturalKey[statusPage]{
+				"subdomain": {
+					path:  func(string) string { return "/api/v2/status-pages" },
+					match: func(s *statusPage, v string) bool { return sameString(s.Subdomain, &v) },
+				},
+			}),
 		},
+		Identity:    idResourceIdentity(),
 		Description: "https://betterstack.com/docs/uptime/api/status-pages/",
 		Schema:      statusPageSchema,
 	}
@@ -387,14 +395,21 @@ func statusPageCreate(ctx context.Context, d *schema.ResourceData, meta interfac
 			load(d, e.k, e.v)
 		}
 	}
+	password := in.Password
+	if err := loadWriteOnly(d, "password", &in.Password); err != nil {
+		return diag.FromErr(err)
+	}
 	var out statusPageHTTPResponse
-	if err := resourceCreate(ctx, meta, "/api/v2/status-pages", &in, &out); err != nil {
+	adopt := adoptSingle(meta, "/api/v2/status-pages", "the same subdomain", func(s *statusPage) bool {
+		return sameString(s.Subdomain, in.Subdomain)
+	}, func(s *statusPage) *string { return s.CreatedAt }, &out.Data.ID, &out.Data.Attributes)
+	if err := resourceCreateReconciled(ctx, meta, "/api/v2/status-pages", &in, &out, adopt); err != nil {
 		return err
 	}
 	d.SetId(out.Data.ID)
 	// Set password from user input since it's not included in the API response
 	var derr diag.Diagnostics
-	if err := d.Set("password", in.Password); err != nil {
+	if err := d.Set("password", password); err != nil {
 		derr = append(derr, diag.FromErr(err)[0])
 	}
 	return statusPageCopyAttrs(d, &out.Data.Attributes, derr)
@@ -463,6 +478,9 @@ func statusPageUpdate(ctx context.Context, d *schema.ResourceData, meta interfac
 			}
 		}
 	}
+	if err := loadWriteOnly(d, "password", &in.Password); err != nil {
+		return diag.FromErr(err)
+	}
 	if err := resourceUpdate(ctx, meta, fmt.Sprintf("/api/v2/status-pages/%s", url.PathEscape(d.Id())), &in, &out); err != nil {
 		return err
 	}
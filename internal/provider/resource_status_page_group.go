@@ -55,6 +55,7 @@ func newStatusPageGroupResource() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Identity:      idResourceIdentity(),
 		Description:   "https://betterstack.com/docs/uptime/api/status-page-groups/",
 		CustomizeDiff: validateTeamNameNotChanged,
 		Schema:        statusPageGroupSchema,
@@ -97,7 +98,7 @@ func statusPageGroupCreate(ctx context.Context, d *schema.ResourceData, meta int
 	for _, e := range statusPageGroupRef(&in) {
 		load(d, e.k, e.v)
 	}
-	load(d, "team_name", &in.TeamName)
+	loadTeamName(d, meta, &in.TeamName)
 	var out statusPageGroupHTTPResponse
 	if err := resourceCreate(ctx, meta, "/api/v2/status-page-groups", &in, &out); err != nil {
 		return err
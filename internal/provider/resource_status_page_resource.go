@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -60,7 +61,6 @@ var statusPageResourceSchema = map[string]*schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
 		Computed:    true,
-		Deprecated:  "Use widget_type instead.",
 	},
 	// TODO: add 'effective_position' computed property?
 	"position": {
@@ -357,9 +357,92 @@ func newStatusPageResourceResource() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
-		Description: "https://betterstack.com/docs/uptime/api/status-page-resources/",
-		Schema:      statusPageResourceSchema,
+		Identity:      nestedResourceIdentity("status_page_id", "The ID of the Status Page."),
+		Description:   "https://betterstack.com/docs/uptime/api/status-page-resources/",
+		Schema:        statusPageResourceSchema,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    statusPageResourceResourceV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: statusPageResourceStateUpgradeV0,
+			},
+		},
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{validateStatusPageResourceDeprecations},
+	}
+}
+
+// statusPageResourceWidgetType returns the widget_type the deprecated history stands for.
+func statusPageResourceWidgetType(history bool) string {
+	if history {
+		return "history"
+	}
+	return "plain"
+}
+
+// statusPageResourceResourceV0 is the schema of betteruptime_status_page_resource states of version
+// 0. It must not change along with statusPageResourceSchema, as it describes states already written.
+func statusPageResourceResourceV0() *schema.Resource {
+	metadataRule := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key":            {Type: schema.TypeString, Required: true},
+			"metadata_value": {Type: schema.TypeList, Required: true, Elem: metadataValueResourceV0()},
+		},
+	}
+	statusHistory := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"day":                  {Type: schema.TypeString, Optional: true, Computed: true},
+			"status":               {Type: schema.TypeString, Optional: true, Computed: true},
+			"downtime_duration":    {Type: schema.TypeInt, Optional: true, Computed: true},
+			"maintenance_duration": {Type: schema.TypeInt, Optional: true, Computed: true},
+		},
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"id":                             {Type: schema.TypeString, Computed: true},
+			"status_page_id":                 {Type: schema.TypeString, Required: true},
+			"status_page_section_id":         {Type: schema.TypeInt, Optional: true, Computed: true},
+			"resource_id":                    {Type: schema.TypeInt, Optional: true, Computed: true},
+			"resource_type":                  {Type: schema.TypeString, Required: true},
+			"public_name":                    {Type: schema.TypeString, Required: true},
+			"explanation":                    {Type: schema.TypeString, Optional: true, Computed: true},
+			"history":                        {Type: schema.TypeBool, Optional: true, Computed: true},
+			"position":                       {Type: schema.TypeInt, Optional: true, Computed: true},
+			"widget_type":                    {Type: schema.TypeString, Optional: true, Computed: true},
+			"availability":                   {Type: schema.TypeFloat, Computed: true},
+			"status_history":                 {Type: schema.TypeList, Computed: true, Elem: statusHistory},
+			"status":                         {Type: schema.TypeString, Computed: true},
+			"mark_as_down_for":               {Type: schema.TypeString, Optional: true, Computed: true},
+			"mark_as_down_metadata_rule":     {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: metadataRule},
+			"mark_as_degraded_for":           {Type: schema.TypeString, Optional: true, Computed: true},
+			"mark_as_degraded_metadata_rule": {Type: schema.TypeList, Optional: true, MaxItems: 1, Elem: metadataRule},
+		},
+	}
+}
+
+// statusPageResourceStateUpgradeV0 sets widget_type from the deprecated history in states written
+// before widget_type was added. history is kept, as it's still returned by the API.
+func statusPageResourceStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if widgetType, _ := rawState["widget_type"].(string); widgetType == "" {
+		if history, ok := rawState["history"].(bool); ok {
+			rawState["widget_type"] = statusPageResourceWidgetType(history)
+		}
+	}
+	return rawState, nil
+}
+
+func validateStatusPageResourceDeprecations(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !configSet(req.RawConfig, "history") {
+		return
+	}
+	var replacement []string
+	if widgetType, ok := configAttr(req.RawConfig, "widget_type"); ok {
+		// widget_type takes preference, history can just be removed.
+		replacement = append(replacement, formatHCL("", "widget_type", widgetType.AsString()))
+	} else if history, ok := configAttr(req.RawConfig, "history"); ok {
+		replacement = append(replacement, formatHCL("", "widget_type", statusPageResourceWidgetType(history.True())))
 	}
+	resp.Diagnostics = append(resp.Diagnostics, deprecatedAttributeWarning(cty.GetAttrPath("history"), "history", "widget_type", replacement...))
 }
 
 type statusPageResource struct {
@@ -58,6 +58,7 @@ func newStatusPageSectionResource() *schema.Resource {
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		Identity:    nestedResourceIdentity("status_page_id", "The ID of the Status Page."),
 		Description: "https://betterstack.com/docs/uptime/api/status-page-sections/",
 		Schema:      statusPageSectionSchema,
 	}
@@ -1,15 +1,13 @@
 package provider
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"log"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -113,12 +111,15 @@ func newTeamMemberResource() *schema.Resource {
 		DeleteContext: teamMemberDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// The ID is the email already, so email=... is accepted for consistency with other resources.
+				d.SetId(strings.TrimPrefix(d.Id(), "email="))
 				if err := d.Set("email", d.Id()); err != nil {
 					return nil, err
 				}
 				return []*schema.ResourceData{d}, nil
 			},
 		},
+		Identity:    idResourceIdentity(),
 		Description: "Allows managing **non-admin team members** using Terraform. Learn more about [inviting team members](https://betterstack.com/docs/uptime/inviting-team-members/).",
 		Schema:      teamMemberSchema,
 	}
@@ -138,33 +139,10 @@ func teamMemberCreate(ctx context.Context, d *schema.ResourceData, meta interfac
 		load(d, "role", &in.Role)
 	}
 
-	reqBody, err := json.Marshal(&in)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	baseURL := meta.(*client).BetterStackBaseURL()
-	log.Printf("POST %s/api/v2/team-members: %s", baseURL, string(reqBody))
-	res, err := meta.(*client).PostWithBaseURL(ctx, baseURL, "/api/v2/team-members", bytes.NewReader(reqBody))
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	// Accept both 201 (invitation created) and 200 (already a member)
-	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("POST /api/v2/team-members returned %d: %s", res.StatusCode, string(body))
-
 	var out teamMemberHTTPResponse
-	if err := json.Unmarshal(body, &out); err != nil {
-		return diag.FromErr(err)
+	// Accept both 201 (invitation created) and 200 (already a member)
+	if _, err := apiDo(ctx, betterStackAPI(meta), http.MethodPost, "/api/v2/team-members", &in, &out, http.StatusCreated, http.StatusOK); err != nil {
+		return apiErrorDiagnostics(err, &in)
 	}
 
 	d.SetId(d.Get("email").(string))
@@ -199,7 +177,7 @@ func teamMemberDelete(ctx context.Context, d *schema.ResourceData, meta interfac
 		path += fmt.Sprintf("&team_name=%s", url.QueryEscape(v.(string)))
 	}
 
-	return resourceDeleteWithBaseURL(ctx, meta, meta.(*client).BetterStackBaseURL(), path)
+	return diag.FromErr(apiDelete(ctx, betterStackAPI(meta), path))
 }
 
 func teamMemberUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -264,27 +242,9 @@ func teamMemberReconcileRole(ctx context.Context, d *schema.ResourceData, meta i
 // teamMemberPostChangeRole POSTs the change-role endpoint and copies the returned
 // member back into state. Shared by create (reconciling an adopted member) and update.
 func teamMemberPostChangeRole(ctx context.Context, d *schema.ResourceData, meta interface{}, memberID, roleID string) diag.Diagnostics {
-	baseURL := meta.(*client).BetterStackBaseURL()
 	path := fmt.Sprintf("/api/v2/team-members/%s/change-role/%s", url.PathEscape(memberID), url.PathEscape(roleID))
-	log.Printf("POST %s%s", baseURL, path)
-	res, err := meta.(*client).PostWithBaseURL(ctx, baseURL, path, nil)
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	defer func() {
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return diag.Errorf("POST %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-	}
-	if err != nil {
-		return diag.FromErr(err)
-	}
-
 	var out teamMemberHTTPResponse
-	if err := json.Unmarshal(body, &out); err != nil {
+	if _, err := apiDo(ctx, betterStackAPI(meta), http.MethodPost, path, nil, &out, http.StatusOK); err != nil {
 		return diag.FromErr(err)
 	}
 	return teamMemberCopyAttrs(d, &out)
@@ -338,34 +298,11 @@ func teamMemberReadByEmail(ctx context.Context, d *schema.ResourceData, meta int
 		path += fmt.Sprintf("&team_name=%s", url.QueryEscape(v.(string)))
 	}
 
-	baseURL := meta.(*client).BetterStackBaseURL()
-	log.Printf("GET %s%s", baseURL, path)
-	res, err := meta.(*client).GetWithBaseURL(ctx, baseURL, path)
-	if err != nil {
-		return nil, diag.FromErr(err), false
-	}
-	defer func() {
-		_, _ = io.Copy(io.Discard, res.Body)
-		_ = res.Body.Close()
-	}()
-
-	if res.StatusCode == http.StatusNotFound {
-		return nil, nil, false
-	}
-	body, err := io.ReadAll(res.Body)
-	if res.StatusCode != http.StatusOK {
-		return nil, diag.Errorf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body)), false
-	}
+	out, ok, err := apiGet[teamMemberHTTPResponse](ctx, betterStackAPI(meta), path)
 	if err != nil {
 		return nil, diag.FromErr(err), false
 	}
-	log.Printf("GET %s returned %d: %s", res.Request.URL.String(), res.StatusCode, string(body))
-
-	var out teamMemberHTTPResponse
-	if err := json.Unmarshal(body, &out); err != nil {
-		return nil, diag.FromErr(err), false
-	}
-	return &out, nil, true
+	return out, nil, ok
 }
 
 func teamMemberCopyAttrs(d *schema.ResourceData, out *teamMemberHTTPResponse) diag.Diagnostics {
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,8 +10,10 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -142,3 +145,122 @@ func (ts *TestServer) TestCheckCalledRequest(method, url, body string) resource.
 		return fmt.Errorf(`expected request %s %s with body "%s" not found`, method, url, body)
 	}
 }
+
+func TestResourceCreateReconciled(t *testing.T) {
+	var posts atomic.Int32
+	var created atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.RequestURI == "/api/v2/items":
+			switch posts.Add(1) {
+			case 1:
+				// Fail before the object is created.
+				w.WriteHeader(http.StatusBadGateway)
+			case 2:
+				// Create the object, but drop the connection before responding.
+				created.Store(true)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatal(err)
+				}
+				_ = conn.Close()
+			default:
+				t.Errorf("Unexpected create attempt %d", posts.Load())
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"data":{"id":"2","attributes":{"name":"duplicate"}}}`))
+			}
+		case r.Method == http.MethodGet && r.RequestURI == "/api/v2/items?page=1":
+			// Objects with the same name created before the create, without a creation time, or in
+			// another team, are never adopted.
+			now := time.Now().UTC().Format(time.RFC3339)
+			items := `{"id":"0","attributes":{"name":"one","team_name":"Ops","created_at":"2020-01-01T00:00:00.000Z"}},` +
+				`{"id":"3","attributes":{"name":"one","team_name":"Ops"}},` +
+				`{"id":"4","attributes":{"name":"one","team_name":"Other","created_at":"` + now + `"}}`
+			if created.Load() {
+				items += `,{"id":"1","attributes":{"name":"one","team_name":"Ops","created_at":"` + now + `"}}`
+			}
+			_, _ = w.Write([]byte(`{"data":[` + items + `],"pagination":{"next":null}}`))
+		default:
+			t.Errorf("Unexpected %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token", RetryMax: 3, RetryWaitMin: time.Millisecond, RetryWaitMax: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	name, team := "one", "Ops"
+	in := apiTestItem{Name: &name, TeamName: &team}
+	var out apiDocument[apiTestItem]
+	adopt := adoptSingle(c, "/api/v2/items", "the same name", func(e *apiTestItem) bool {
+		return sameString(e.Name, in.Name) && sameTeam(e.TeamName, in.TeamName)
+	}, func(e *apiTestItem) *string { return e.CreatedAt }, &out.Data.ID, &out.Data.Attributes)
+	if diags := resourceCreateReconciled(context.Background(), c, "/api/v2/items", &in, &out, adopt); diags.HasError() {
+		t.Fatalf("Create failed: %v", diags)
+	}
+
+	if posts.Load() != 2 {
+		t.Errorf("Expected 2 create attempts, got %d", posts.Load())
+	}
+	if out.Data.ID != "1" || *out.Data.Attributes.Name != "one" {
+		t.Errorf("Expected the created object to be adopted, got %+v", out.Data)
+	}
+}
+
+func TestImportStateByNaturalKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.RequestURI != "/api/v2/items?page=1" {
+			t.Errorf("Unexpected %s %s", r.Method, r.RequestURI)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"1","attributes":{"name":"one"}},{"id":"2","attributes":{"name":"dup"}},{"id":"3","attributes":{"name":"dup"}}],"pagination":{"next":null}}`))
+	}))
+	defer server.Close()
+
+	c, err := newClient(ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	importer := importStateByNaturalKey("betteruptime_item", map[string]naturalKey[apiTestItem]{
+		"name": {
+			path:  func(string) string { return "/api/v2/items" },
+			match: func(e *apiTestItem, v string) bool { return sameString(e.Name, &v) },
+		},
+	})
+	r := &schema.Resource{Schema: map[string]*schema.Schema{"name": {Type: schema.TypeString, Optional: true}}}
+
+	for _, tc := range []struct {
+		id       string
+		expected string
+		err      string
+	}{
+		{id: "42", expected: "42"},
+		{id: "name=one", expected: "1"},
+		{id: "name=dup", err: `found 2 betteruptime_item objects with name "dup" (IDs 2, 3), import one of them by ID instead`},
+		{id: "name=none", err: `no betteruptime_item with name "none" found`},
+		{id: "url=one", err: `betteruptime_item must be imported by ID or name=..., got "url=one"`},
+	} {
+		t.Run(tc.id, func(t *testing.T) {
+			d := r.TestResourceData()
+			d.SetId(tc.id)
+			_, err := importer(context.Background(), d, c)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("Expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Import failed: %v", err)
+			}
+			if d.Id() != tc.expected {
+				t.Errorf("Expected ID %q, got %q", tc.expected, d.Id())
+			}
+		})
+	}
+}
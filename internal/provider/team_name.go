@@ -13,7 +13,7 @@ import (
 // different non-empty value is rejected (see validateTeamNameNotChanged).
 func teamNameSchema() *schema.Schema {
 	return &schema.Schema{
-		Description: "Used to specify the team the resource should be created in when using global tokens. You can't update this value later.",
+		Description: "Used to specify the team the resource should be created in when using global tokens. Defaults to the provider's `default_team_name`. You can't update this value later.",
 		Type:        schema.TypeString,
 		Optional:    true,
 		Default:     nil,
@@ -23,6 +23,25 @@ func teamNameSchema() *schema.Schema {
 	}
 }
 
+// loadTeamName loads team_name like load, falling back to the provider's default_team_name when it
+// isn't set. The default is only sent to the API and never stored in the state, so that it doesn't
+// show up as a change, and changing it later doesn't affect existing resources, like team_name.
+func loadTeamName(d *schema.ResourceData, meta interface{}, receiver **string) {
+	load(d, "team_name", receiver)
+	if *receiver != nil && **receiver != "" {
+		return
+	}
+	if name := meta.(*client).defaultTeamName; name != "" {
+		*receiver = &name
+	}
+}
+
+// sameTeam reports whether an object listed by the API may be in team, the team an object is being
+// created in. The API only reports the team of objects listed using a global API token.
+func sameTeam(listed, team *string) bool {
+	return team == nil || *team == "" || listed == nil || *listed == *team
+}
+
 // validateTeamNameNotChanged rejects changing team_name to a different, non-empty value after a
 // resource has been created. team_name only selects the team when the resource is created with a
 // global API token and is ignored afterwards, so silently dropping the change ("No changes") was
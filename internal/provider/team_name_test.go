@@ -70,3 +70,76 @@ func TestTeamNameCannotBeChangedAfterCreate(t *testing.T) {
 		},
 	})
 }
+
+// TestDefaultTeamName verifies that resources without team_name are created in the provider's
+// default_team_name, without the default showing up in the state.
+func TestDefaultTeamName(t *testing.T) {
+	server := newResourceServer(t, "/api/v2/urgency-groups", "1")
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"betteruptime": func() (*schema.Provider, error) {
+				return New(WithURL(server.URL)), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				provider "betteruptime" {
+					api_token         = "foo"
+					default_team_name = "Default team"
+				}
+
+				resource "betteruptime_severity_group" "this" {
+					name       = "example"
+					sort_index = 1
+				}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					server.TestCheckCalledRequest("POST", "/api/v2/urgency-groups", `{"name":"example","sort_index":1,"team_name":"Default team"}`),
+					resource.TestCheckNoResourceAttr("betteruptime_severity_group.this", "team_name"),
+				),
+			},
+			// Changing the default doesn't affect existing resources.
+			{
+				Config: `
+				provider "betteruptime" {
+					api_token         = "foo"
+					default_team_name = "Other team"
+				}
+
+				resource "betteruptime_severity_group" "this" {
+					name       = "example"
+					sort_index = 1
+				}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestLoadTeamName(t *testing.T) {
+	s := map[string]*schema.Schema{"team_name": teamNameSchema()}
+	meta := &client{defaultTeamName: "Default team"}
+
+	var name *string
+	loadTeamName(schema.TestResourceDataRaw(t, s, map[string]interface{}{}), meta, &name)
+	if name == nil || *name != "Default team" {
+		t.Errorf("Expected the default team, got %v", name)
+	}
+
+	name = nil
+	loadTeamName(schema.TestResourceDataRaw(t, s, map[string]interface{}{"team_name": "Ops"}), meta, &name)
+	if name == nil || *name != "Ops" {
+		t.Errorf("Expected the configured team, got %v", name)
+	}
+
+	name = nil
+	loadTeamName(schema.TestResourceDataRaw(t, s, map[string]interface{}{}), &client{}, &name)
+	if name != nil {
+		t.Errorf("Expected no team without a default, got %q", *name)
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// tokenCommandResults caches the tokens returned by api_token_command for the lifetime of the plugin
+// process, so that the command doesn't run again when the provider is configured again in it. The
+// cache isn't shared between processes: Terraform starts a separate one for every provider
+// configuration, aliases included, and each of them runs the command.
+var tokenCommandResults = struct {
+	sync.Mutex
+	tokens map[string]string
+}{tokens: map[string]string{}}
+
+// resolveAPIToken returns the API token from api_token_file or api_token_command if one of them is
+// set, and from api_token (or the BETTERUPTIME_API_TOKEN environment variable) otherwise.
+func resolveAPIToken(ctx context.Context, r *schema.ResourceData) (string, diag.Diagnostics) {
+	if path := r.Get("api_token_file").(string); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", tokenDiagnostics("api_token_file", "Failed to read API token file", err.Error())
+		}
+		token := strings.TrimSpace(string(b))
+		if token == "" {
+			return "", tokenDiagnostics("api_token_file", "API token file is empty", fmt.Sprintf("%s contains no API token.", path))
+		}
+		return token, nil
+	}
+
+	if raw := r.Get("api_token_command").([]interface{}); len(raw) > 0 {
+		args := make([]string, len(raw))
+		for i, v := range raw {
+			args[i], _ = v.(string)
+		}
+		return runTokenCommand(ctx, args)
+	}
+
+	token := r.Get("api_token").(string)
+	if token == "" {
+		return "", tokenDiagnostics("api_token", "No API token configured", "Set api_token, api_token_file or api_token_command, or the BETTERUPTIME_API_TOKEN environment variable.")
+	}
+	return token, nil
+}
+
+// runTokenCommand runs the api_token_command and returns the token it prints on stdout.
+func runTokenCommand(ctx context.Context, args []string) (string, diag.Diagnostics) {
+	key := strings.Join(args, "\x00")
+	tokenCommandResults.Lock()
+	defer tokenCommandResults.Unlock()
+	if token, ok := tokenCommandResults.tokens[key]; ok {
+		return token, nil
+	}
+
+	if args[0] == "" {
+		return "", tokenDiagnostics("api_token_command", "Invalid API token command", "The first element must be the command to run.")
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		detail := fmt.Sprintf("Running %s failed: %v", args[0], err)
+		if s := strings.TrimSpace(stderr.String()); s != "" {
+			detail += "\n\n" + s
+		}
+		return "", tokenDiagnostics("api_token_command", "API token command failed", detail)
+	}
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", tokenDiagnostics("api_token_command", "API token command returned no token", fmt.Sprintf("%s printed nothing on stdout.", args[0]))
+	}
+	tokenCommandResults.tokens[key] = token
+	return token, nil
+}
+
+func tokenDiagnostics(attribute, summary, detail string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: cty.GetAttrPath(attribute),
+	}}
+}
+
+// tokenScope is what the token check found out about the API token.
+type tokenScope int
+
+const (
+	tokenScopeUnknown tokenScope = iota
+	// tokenScopeGlobal tokens can access all teams of the organization. Resources are created in
+	// the team given by team_name.
+	tokenScopeGlobal
+	// tokenScopeTeam tokens are bound to a single team. team_name is ignored.
+	tokenScopeTeam
+)
+
+// checkToken makes a cheap authenticated request to fail fast on an invalid token, then lists the
+// organization's teams, which only global tokens may do, to tell global tokens from team-scoped
+// ones. When either request fails for another reason, the scope stays unknown and a warning is
+// returned; resource operations will report the actual problem.
+func checkToken(ctx context.Context, c *client) (tokenScope, diag.Diagnostics) {
+	_, err := apiDo(ctx, uptimeAPI(c), http.MethodGet, "/api/v2/monitors?per_page=1", nil, nil, http.StatusOK)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden) {
+		return tokenScopeUnknown, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid API token",
+			Detail:   fmt.Sprintf("The Uptime API rejected the API token: %v", err),
+		}}
+	}
+	if err != nil {
+		return tokenScopeUnknown, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Couldn't check the API token",
+			Detail:   err.Error(),
+		}}
+	}
+
+	status, err := apiDo(ctx, betterStackAPI(c), http.MethodGet, "/api/v2/teams", nil, nil, http.StatusOK, http.StatusForbidden)
+	switch {
+	case err != nil:
+		tflog.Debug(ctx, "Couldn't determine the API token scope", map[string]interface{}{"error": err.Error()})
+		return tokenScopeUnknown, nil
+	case status == http.StatusForbidden:
+		return tokenScopeTeam, nil
+	default:
+		return tokenScopeGlobal, nil
+	}
+}
+
+// teamNameWarnings records the team_name values withTeamNameWarning warned about in the plugin
+// process, so that refreshing many resources with the same team_name warns about it only once.
+var teamNameWarnings = struct {
+	sync.Mutex
+	warned map[string]bool
+}{warned: map[string]bool{}}
+
+// withTeamNameWarning wraps the create, read and update functions of resources with a team_name
+// attribute to warn when it's set while using a team-scoped token, which ignores it. Wrapping read
+// shows the warning for existing resources too, when they're refreshed during plan.
+func withTeamNameWarning(r *schema.Resource) *schema.Resource {
+	if _, ok := r.Schema["team_name"]; !ok {
+		return r
+	}
+	r.CreateContext = withTeamNameWarningFunc(r.CreateContext)
+	r.ReadContext = withTeamNameWarningFunc(r.ReadContext)
+	r.UpdateContext = withTeamNameWarningFunc(r.UpdateContext)
+	return r
+}
+
+func withTeamNameWarningFunc[F ~func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics](f F) F {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return append(f(ctx, d, meta), teamNameWarning(d, meta)...)
+	}
+}
+
+// teamNameWarning returns a warning if team_name is set on d while using a team-scoped token,
+// unless the same team_name was already warned about.
+func teamNameWarning(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c, ok := meta.(*client)
+	if !ok || c.tokenScope != tokenScopeTeam || d.Id() == "" {
+		return nil
+	}
+	v, ok := d.GetOk("team_name")
+	if !ok {
+		return nil
+	}
+	teamNameWarnings.Lock()
+	defer teamNameWarnings.Unlock()
+	if teamNameWarnings.warned[v.(string)] {
+		return nil
+	}
+	teamNameWarnings.warned[v.(string)] = true
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       "team_name is ignored",
+		Detail:        fmt.Sprintf("team_name = %q has no effect, because the API token is bound to a single team. Resources are created in the token's team; team_name only selects the team when using a global API token.", v),
+		AttributePath: cty.GetAttrPath("team_name"),
+	}}
+}
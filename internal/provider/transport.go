@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TransportConfig configures how the client connects to the API. Certificates and keys are given
+// either PEM-encoded or as the path to a PEM file.
+type TransportConfig struct {
+	ProxyURL          string
+	CABundle          string
+	ClientCertificate string
+	ClientKey         string
+	TLSMinVersion     string
+}
+
+// newTransport returns an HTTP transport like http.DefaultTransport, adjusted to config. Without an
+// explicit proxy URL, the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment variables are honored.
+func newTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	// Go's default may change between releases, api_tls_min_version documents 1.2.
+	transport.TLSClientConfig.MinVersion = tls.VersionTLS12
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("api_proxy_url: invalid proxy URL %q", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.CABundle != "" {
+		pem, err := readPEM(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("api_ca_bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("api_ca_bundle: no PEM-encoded certificates found")
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if config.ClientCertificate != "" || config.ClientKey != "" {
+		if config.ClientCertificate == "" || config.ClientKey == "" {
+			return nil, fmt.Errorf("api_client_certificate and api_client_key must be set together")
+		}
+		certPEM, err := readPEM(config.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("api_client_certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("api_client_key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("api_client_certificate: %w", err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.TLSMinVersion != "" {
+		version, ok := tlsVersions[config.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("api_tls_min_version: unsupported TLS version %q", config.TLSMinVersion)
+		}
+		transport.TLSClientConfig.MinVersion = version
+	}
+
+	return transport, nil
+}
+
+// readPEM returns v if it's PEM-encoded, and the contents of the file at path v otherwise.
+func readPEM(v string) ([]byte, error) {
+	if strings.Contains(v, "-----BEGIN ") {
+		return []byte(v), nil
+	}
+	b, err := os.ReadFile(v)
+	if err != nil {
+		return nil, fmt.Errorf("reading PEM file: %w", err)
+	}
+	return b, nil
+}
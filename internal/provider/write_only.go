@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// writeOnlySchema returns the schema of k_wo, the write-only counterpart of the sensitive attribute
+// k. Its value is sent to the API on create, and on update when k_wo_version changes, but never
+// stored in the state.
+func writeOnlySchema(k string) *schema.Schema {
+	return &schema.Schema{
+		Description:   fmt.Sprintf("Write-only alternative to `%s`, which isn't stored in the state. Requires Terraform 1.11 or later. Change `%s_wo_version` to update it.", k, k),
+		Type:          schema.TypeString,
+		Optional:      true,
+		WriteOnly:     true,
+		Sensitive:     true,
+		ConflictsWith: []string{k},
+		RequiredWith:  []string{k + "_wo_version"},
+	}
+}
+
+func writeOnlyVersionSchema(k string) *schema.Schema {
+	return &schema.Schema{
+		Description:  fmt.Sprintf("Version of `%s_wo`. The value of `%s_wo` is only sent to the API when the resource is created or this version changes.", k, k),
+		Type:         schema.TypeInt,
+		Optional:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+		RequiredWith: []string{k + "_wo"},
+	}
+}
+
+// loadWriteOnly loads the value of k_wo from the configuration into receiver, which is a pointer to
+// a *string, or a *map[string]string decoded from a JSON object as the SDK doesn't support
+// write-only maps. On update, it's only loaded when
+// k_wo_version changed.
+func loadWriteOnly(d *schema.ResourceData, k string, receiver interface{}) error {
+	if d.Id() != "" && !d.HasChange(k+"_wo_version") {
+		return nil
+	}
+	v, diags := d.GetRawConfigAt(cty.GetAttrPath(k + "_wo"))
+	if diags.HasError() || v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	switch x := receiver.(type) {
+	case **string:
+		s := v.AsString()
+		*x = &s
+	case **map[string]string:
+		var m map[string]string
+		if err := json.Unmarshal([]byte(v.AsString()), &m); err != nil {
+			return fmt.Errorf("%s_wo must be a JSON object of strings: %w", k, err)
+		}
+		*x = &m
+	default:
+		panic(fmt.Errorf("unexpected type %T", receiver))
+	}
+	return nil
+}
+
+// usesWriteOnly returns true if the resource sets k through k_wo, in which case the value of k
+// returned by the API must not be stored in the state. Data sources don't have k_wo_version.
+func usesWriteOnly(d *schema.ResourceData, k string) bool {
+	version, _ := d.Get(k + "_wo_version").(int)
+	return version != 0
+}
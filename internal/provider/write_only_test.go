@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestWriteOnlyValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		config   map[string]interface{}
+		valid    bool
+	}{
+		{"write-only", "betteruptime_pagerduty_integration", map[string]interface{}{"severity": "info", "key_wo": "k", "key_wo_version": 1}, true},
+		{"both", "betteruptime_pagerduty_integration", map[string]interface{}{"severity": "info", "key": "k", "key_wo": "k", "key_wo_version": 1}, false},
+		{"neither", "betteruptime_pagerduty_integration", map[string]interface{}{"severity": "info"}, false},
+		{"missing version", "betteruptime_splunk_oncall_integration", map[string]interface{}{"url_wo": "https://example.com"}, false},
+		{"version only", "betteruptime_status_page", map[string]interface{}{"company_name": "c", "company_url": "https://example.com", "timezone": "UTC", "subdomain": "s", "password_wo_version": 1}, false},
+		{"both passwords", "betteruptime_monitor", map[string]interface{}{"monitor_type": "status", "url": "https://example.com", "auth_password": "p", "auth_password_wo": "p", "auth_password_wo_version": 1}, false},
+		{"environment variables", "betteruptime_monitor", map[string]interface{}{"monitor_type": "playwright", "scenario_name": "s", "environment_variables_wo": `{"PASSWORD":"p"}`, "environment_variables_wo_version": 1}, true},
+		{"invalid environment variables", "betteruptime_monitor", map[string]interface{}{"monitor_type": "playwright", "scenario_name": "s", "environment_variables_wo": "PASSWORD=p", "environment_variables_wo_version": 1}, false},
+	}
+	p := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := p.ResourcesMap[tt.resource].Validate(terraform.NewResourceConfigRaw(tt.config))
+			if diags.HasError() == tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, diags)
+			}
+		})
+	}
+}
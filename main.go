@@ -1,15 +1,14 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"os"
 
 	"github.com/BetterStackHQ/terraform-provider-better-uptime/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Format Terraform examples/.
@@ -27,6 +26,10 @@ func main() {
 
 	flag.BoolVar(&printVersionAndExit, "version", false, "print version")
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags]\n       %s export [-dir DIR]\n\nFlags:\n", os.Args[0], os.Args[0])
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	if printVersionAndExit {
@@ -34,20 +37,38 @@ func main() {
 		return
 	}
 
-	// Do not include a timestamp (terraform includes it already).
-	log.SetFlags(log.Lshortfile)
-	// Log request/response bodies (that may contain sensitive data) if (and only if)
-	// TF_PROVIDER_BETTERUPTIME_LOG_INSECURE env var is set to 1.
-	if os.Getenv("TF_PROVIDER_BETTERUPTIME_LOG_INSECURE") != "1" {
-		log.SetOutput(io.Discard)
+	ctx := context.Background()
+	if flag.Arg(0) == "export" {
+		export(ctx, flag.Args()[1:])
+		return
 	}
 
-	opts := &plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return provider.New(provider.WithVersion(version))
-		},
-		Debug: debugMode,
+	server, err := provider.NewProviderServer(ctx, provider.WithVersion(version), provider.WithTokenCheck())
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	plugin.Serve(opts)
+	var serveOpts []tf5server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
+	}
+
+	if err := tf5server.Serve("registry.terraform.io/BetterStackHQ/better-uptime", server, serveOpts...); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// export writes the configuration and import blocks of all objects accessible with the API token,
+// see provider.Export. The provider is configured with the usual environment variables, e.g.
+// BETTERUPTIME_API_TOKEN.
+func export(ctx context.Context, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	dir := fs.String("dir", ".", "directory to write the betteruptime_<type>.tf files to, which must not have any yet")
+	_ = fs.Parse(args)
+
+	n, err := provider.Export(ctx, *dir, provider.WithVersion(version))
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Exported %d objects to %s\n", n, *dir)
 }